@@ -103,8 +103,7 @@ func createMenu() {
 	c1 := m.NewItem("Black & Yellow", "BY")
 	c2 := m.NewItem("Black & Red", "BR")
 
-	// When using NewForm & NewMenu (passing a pointer to array of pointers in C) remember to add a nil entry to the end of your slice.
-	items := []*m.Item{c1, c2, nil}
+	items := []*m.Item{c1, c2}
 	menu, _ = m.NewMenu(items)
 
 	menu.SetWin(windows[1].w)
@@ -134,8 +133,7 @@ func createForm() {
 	f1, _ := NewField(1, 10, 4, 10, 0, 0)
 	f2, _ := NewField(1, 10, 6, 10, 0, 0)
 
-	// When using NewForm & NewMenu (passing a pointer to array of pointers in C) remember to add a nil entry to the end of your slice.
-	fields := []*Field{f1, f2, nil}
+	fields := []*Field{f1, f2}
 	form, _ = NewForm(fields)
 
 	form.SetWin(windows[0].w)
@@ -225,7 +223,7 @@ forloop:
 			}
 
 		// -- Change selection --
-		case 339: // pgup
+		case KEY_PPAGE:
 			if current == 0 {
 				form.Drive(REQ_PREV_FIELD)
 				form.Drive(REQ_END_LINE)
@@ -233,7 +231,7 @@ forloop:
 				menu.Drive(m.REQ_PREV_ITEM)
 				currentColor = menu.CurrentItem().Index() + 1
 			}
-		case 338: // pgdown
+		case KEY_NPAGE:
 			if current == 0 {
 				form.Drive(REQ_NEXT_FIELD)
 				form.Drive(REQ_END_LINE)
@@ -247,12 +245,12 @@ forloop:
 			nextPanel()
 
 		// -- Erase characters in a form --
-		case 330: // delete
+		case KEY_DC:
 			if current == 0 {
 				form.Drive(REQ_DEL_CHAR)
 			}
 		case KEY_BACKSPACE:
-			if form.Drive(REQ_PREV_CHAR) {
+			if form.Drive(REQ_PREV_CHAR) == nil {
 				form.Drive(REQ_DEL_CHAR)
 			}
 
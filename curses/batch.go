@@ -0,0 +1,40 @@
+package curses
+
+// Noutrefresher is implemented by anything that can be copied to the
+// virtual screen ahead of a single DoUpdate, such as a Window or a Viewport.
+type Noutrefresher interface {
+	Noutrefresh() error
+}
+
+// Batch collects the windows to be refreshed in one frame, so that the
+// terminal is physically updated once instead of once per window.
+type Batch struct {
+	items []Noutrefresher
+}
+
+func NewBatch(items ...Noutrefresher) *Batch {
+	return &Batch{items: items}
+}
+
+// Add appends items to the batch. Items are copied to the virtual screen in
+// the order they were added, so later items are drawn over earlier ones.
+func (b *Batch) Add(items ...Noutrefresher) *Batch {
+	b.items = append(b.items, items...)
+	return b
+}
+
+// Reset empties the batch so it can be reused for the next frame.
+func (b *Batch) Reset() {
+	b.items = b.items[:0]
+}
+
+// Update copies every item to the virtual screen and then updates the
+// terminal with a single DoUpdate. It stops at the first error.
+func (b *Batch) Update() error {
+	for _, item := range b.items {
+		if err := item.Noutrefresh(); err != nil {
+			return err
+		}
+	}
+	return DoUpdate()
+}
@@ -0,0 +1,158 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+import "C"
+
+import "unsafe"
+
+// Cell is the content of one character cell of a window.
+type Cell struct {
+	Rune      rune   // the spacing character
+	Combining []rune // combining characters drawn over Rune, if any
+	Attrs     int    // attributes, without the color pair
+	Pair      int    // color pair number
+}
+
+func (c Cell) String() string {
+	return string(append([]rune{c.Rune}, c.Combining...))
+}
+
+// Cell returns the characters, attributes and color pair of c.
+func (c *Cchar) Cell() Cell {
+	CheckThread()
+	var wch [C.CCHARW_MAX + 1]C.wchar_t
+	var attrs C.attr_t
+	var pair C.short
+	var ext C.int
+	if C.getcchar((*C.cchar_t)(c), &wch[0], &attrs, &pair, unsafe.Pointer(&ext)) == C.ERR {
+		return Cell{}
+	}
+	cell := Cell{Rune: rune(wch[0]), Attrs: int(attrs &^ C.A_COLOR), Pair: int(ext)}
+	for _, wc := range wch[1:] {
+		if wc == 0 {
+			break
+		}
+		cell.Combining = append(cell.Combining, rune(wc))
+	}
+	return cell
+}
+
+func chtypeCell(ch C.chtype) Cell {
+	return Cell{
+		Rune:  rune(ch & C.A_CHARTEXT),
+		Attrs: int(ch & (C.A_ATTRIBUTES &^ C.A_COLOR)),
+		Pair:  int(C.PAIR_NUMBER(C.int(ch))),
+	}
+}
+
+// remaining clamps n to the columns from the cursor to the right edge of
+// the window. A negative n stands for all of them.
+func (win *Window) remaining(n int) int {
+	x := win.Getcurx()
+	_, maxx := win.Getmaxyx()
+	if n < 0 || n > maxx-x {
+		n = maxx - x
+	}
+	return max(n, 0)
+}
+
+// Instr returns up to n characters starting at the cursor, stopping at the
+// right edge of the window. A negative n reads to the edge.
+func (win *Window) Instr(n int) (string, error) {
+	CheckThread()
+	n = win.remaining(n)
+	buf := make([]C.wchar_t, n+1)
+	r := C.winnwstr((*C.WINDOW)(win), &buf[0], C.int(n))
+	if r == C.ERR {
+		return "", CursesError{"winnwstr", ERR}
+	}
+	rs := make([]rune, r)
+	for i := range rs {
+		rs[i] = rune(buf[i])
+	}
+	return string(rs), nil
+}
+
+func (win *Window) Mvinstr(y, x, n int) (string, error) {
+	if err := win.Move(x, y); err != nil {
+		return "", err
+	}
+	return win.Instr(n)
+}
+
+// Inchstr returns up to n cells starting at the cursor, as stored in the
+// narrow chtype form, so characters outside the single-byte range are not
+// represented faithfully. Use InWchstr for those. A negative n reads to
+// the right edge of the window.
+func (win *Window) Inchstr(n int) ([]Cell, error) {
+	CheckThread()
+	n = win.remaining(n)
+	buf := make([]C.chtype, n+1)
+	r := C.winchnstr((*C.WINDOW)(win), &buf[0], C.int(n))
+	if r == C.ERR {
+		return nil, CursesError{"winchnstr", ERR}
+	}
+	cells := make([]Cell, r)
+	for i := range cells {
+		cells[i] = chtypeCell(buf[i])
+	}
+	return cells, nil
+}
+
+func (win *Window) Mvinchstr(y, x, n int) ([]Cell, error) {
+	if err := win.Move(x, y); err != nil {
+		return nil, err
+	}
+	return win.Inchstr(n)
+}
+
+// InWch returns the cell under the cursor.
+func (win *Window) InWch() (Cell, error) {
+	CheckThread()
+	var c Cchar
+	if C.win_wch((*C.WINDOW)(win), (*C.cchar_t)(&c)) == C.ERR {
+		return Cell{}, CursesError{"win_wch", ERR}
+	}
+	return c.Cell(), nil
+}
+
+func (win *Window) MvinWch(y, x int) (Cell, error) {
+	CheckThread()
+	var c Cchar
+	if C.mvwin_wch((*C.WINDOW)(win), C.int(y), C.int(x), (*C.cchar_t)(&c)) == C.ERR {
+		return Cell{}, CursesError{"mvwin_wch", ERR}
+	}
+	return c.Cell(), nil
+}
+
+// InWchstr returns the cells of up to n columns starting at the cursor,
+// stopping at the right edge of the window. A negative n reads to the edge.
+func (win *Window) InWchstr(n int) ([]Cell, error) {
+	CheckThread()
+	n = win.remaining(n)
+	buf := make([]Cchar, n+1)
+	if C.win_wchnstr((*C.WINDOW)(win), (*C.cchar_t)(&buf[0]), C.int(n)) == C.ERR {
+		return nil, CursesError{"win_wchnstr", ERR}
+	}
+	cells := make([]Cell, 0, n)
+	for i := 0; i < n; i++ {
+		cell := buf[i].Cell()
+		// The trailing columns of a wide character read back as empty
+		// cells; skip them so each Cell stands for one character.
+		if cell.Rune == 0 && i > 0 {
+			continue
+		}
+		cells = append(cells, cell)
+	}
+	return cells, nil
+}
+
+func (win *Window) MvinWchstr(y, x, n int) ([]Cell, error) {
+	if err := win.Move(x, y); err != nil {
+		return nil, err
+	}
+	return win.InWchstr(n)
+}
@@ -0,0 +1,52 @@
+package curses_test
+
+import (
+	"testing"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+)
+
+func TestReadBack(t *testing.T) {
+	term, err := cursestest.New(3, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+	w := term.Stdscr()
+	w.MvaddWstr(1, 0, "ab漢cd")
+
+	// Instr counts characters, InWchstr counts columns.
+	tests := []struct {
+		n           int
+		str, wchstr string
+	}{
+		{-1, "漢cd    ", "漢cd    "},
+		{2, "漢c", "漢"},
+		{100, "漢cd    ", "漢cd    "},
+		{0, "", ""},
+	}
+	for _, tt := range tests {
+		s, err := w.Mvinstr(1, 2, tt.n)
+		if err != nil || s != tt.str {
+			t.Errorf("Mvinstr(1, 2, %d) = %q, %v; want %q", tt.n, s, err, tt.str)
+		}
+		cells, err := w.MvinWchstr(1, 2, tt.n)
+		if err != nil || cellText(cells) != tt.wchstr {
+			t.Errorf("MvinWchstr(1, 2, %d) = %q, %v; want %q", tt.n, cellText(cells), err, tt.wchstr)
+		}
+	}
+
+	cells, err := w.Mvinchstr(1, 5, -1)
+	if err != nil || cellText(cells) != "d    " {
+		t.Errorf("Mvinchstr(1, 5, -1) = %q, %v; want %q", cellText(cells), err, "d    ")
+	}
+}
+
+func cellText(cells []curses.Cell) string {
+	s := ""
+	for _, c := range cells {
+		s += c.String()
+	}
+	return s
+}
@@ -0,0 +1,92 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+import "C"
+
+// The 16 ANSI colors as rendered by xterm, indexed by color number.
+var ansiPalette = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// Levels of the 6x6x6 color cube of 256-color terminals.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// NearestColor returns the color number closest to r, g, b in the default
+// palette of the terminal: the 8 or 16 ANSI colors, or the xterm 256-color
+// cube and gray ramp when COLORS is at least 256.
+func NearestColor(r, g, b uint8) int {
+	CheckThread()
+	colors := int(C.COLORS)
+	if colors < 256 {
+		n := 8
+		if colors >= 16 {
+			n = 16
+		}
+		best, bestDist := 0, -1
+		for i := 0; i < n; i++ {
+			p := ansiPalette[i]
+			if d := colorDist(int(r), int(g), int(b), p[0], p[1], p[2]); bestDist < 0 || d < bestDist {
+				best, bestDist = i, d
+			}
+		}
+		return best
+	}
+
+	ri, gi, bi := cubeIndex(int(r)), cubeIndex(int(g)), cubeIndex(int(b))
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := colorDist(int(r), int(g), int(b), cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	avg := (int(r) + int(g) + int(b)) / 3
+	gi = clamp((avg-8+5)/10, 0, 23)
+	lv := 8 + 10*gi
+	if colorDist(int(r), int(g), int(b), lv, lv, lv) < cubeDist {
+		return 232 + gi
+	}
+	return cube
+}
+
+// ColorRGB returns a color number for r, g, b. If the terminal can redefine
+// its colors and slot is a valid color number, slot is set to exactly r, g, b
+// and returned. Otherwise the nearest palette color is returned.
+func ColorRGB(slot int, r, g, b uint8) (int, error) {
+	CheckThread()
+	if !Can_change_color() || slot < 0 || slot >= int(C.COLORS) {
+		return NearestColor(r, g, b), nil
+	}
+	if err := Init_extended_color(slot, scale1000(r), scale1000(g), scale1000(b)); err != nil {
+		return 0, err
+	}
+	return slot, nil
+}
+
+func cubeIndex(v int) int {
+	best := 0
+	for i, l := range cubeLevels {
+		if abs(v-l) < abs(v-cubeLevels[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+func colorDist(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
+
+func scale1000(v uint8) int {
+	return (int(v)*1000 + 127) / 255
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,101 @@
+package curses_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+)
+
+func startColor(t *testing.T, term string) *cursestest.Terminal {
+	t.Helper()
+	tt, err := cursestest.NewTerm(term, 5, 10)
+	if err != nil {
+		t.Skipf("%s: %v", term, err)
+	}
+	if err := curses.Start_color(); err != nil {
+		tt.Close()
+		t.Fatal(err)
+	}
+	return tt
+}
+
+func TestNearestColor(t *testing.T) {
+	tests := []struct {
+		term    string
+		r, g, b uint8
+		want    int
+	}{
+		{"xterm", 0, 0, 0, curses.COLOR_BLACK},
+		{"xterm", 255, 0, 0, curses.COLOR_RED},
+		{"xterm", 250, 250, 250, curses.COLOR_WHITE},
+		{"xterm", 100, 100, 255, curses.COLOR_BLUE},
+		{"xterm", 127, 127, 127, curses.COLOR_YELLOW},
+		{"xterm", 200, 200, 200, curses.COLOR_WHITE},
+		{"xterm-16color", 255, 0, 0, 9},
+		{"xterm-16color", 127, 127, 127, 8},
+		{"xterm-16color", 100, 100, 255, 12},
+		{"xterm-256color", 0, 0, 0, 16},
+		{"xterm-256color", 255, 255, 255, 231},
+		{"xterm-256color", 255, 0, 0, 196},
+		{"xterm-256color", 0, 95, 135, 24},
+		{"xterm-256color", 8, 8, 8, 232},
+		{"xterm-256color", 128, 128, 128, 244},
+		{"xterm-256color", 238, 238, 238, 255},
+		// Near gray but closer to a cube color than to the ramp.
+		{"xterm-256color", 95, 95, 95, 59},
+	}
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			term := startColor(t, tt.term)
+			defer term.Close()
+			if got := curses.NearestColor(tt.r, tt.g, tt.b); got != tt.want {
+				t.Errorf("NearestColor(%d, %d, %d) = %d, want %d", tt.r, tt.g, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorRGB(t *testing.T) {
+	t.Run("fixed palette", func(t *testing.T) {
+		term := startColor(t, "xterm")
+		defer term.Close()
+		if c, err := curses.ColorRGB(5, 255, 0, 0); err != nil || c != curses.COLOR_RED {
+			t.Errorf("ColorRGB(5, red) = %d, %v; want %d", c, err, curses.COLOR_RED)
+		}
+	})
+
+	t.Run("changeable", func(t *testing.T) {
+		term := startColor(t, "xterm-256color")
+		defer term.Close()
+		if !curses.Can_change_color() {
+			t.Skip("xterm-256color cannot change colors here")
+		}
+		c, err := curses.ColorRGB(100, 10, 20, 255)
+		if err != nil || c != 100 {
+			t.Fatalf("ColorRGB(100, ...) = %d, %v; want 100", c, err)
+		}
+		r, g, b, err := curses.Extended_color_content(100)
+		if err != nil || r != 39 || g != 78 || b != 1000 {
+			t.Errorf("color 100 = %d, %d, %d, %v; want 39, 78, 1000", r, g, b, err)
+		}
+		if c, err := curses.ColorRGB(-1, 255, 0, 0); err != nil || c != 196 {
+			t.Errorf("ColorRGB(-1, red) = %d, %v; want 196", c, err)
+		}
+		if c, err := curses.ColorRGB(256, 255, 0, 0); err != nil || c != 196 {
+			t.Errorf("ColorRGB(256, red) = %d, %v; want 196", c, err)
+		}
+	})
+}
+
+func TestStartColorWithoutColors(t *testing.T) {
+	term, err := cursestest.NewTerm("vt100", 5, 10)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer term.Close()
+	if err := curses.Start_color(); !errors.Is(err, curses.CursesError{Op: "has_colors", Code: curses.ERR}) {
+		t.Errorf("Start_color on vt100 = %v, want a has_colors error", err)
+	}
+}
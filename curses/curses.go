@@ -15,15 +15,10 @@ import (
 
 type void unsafe.Pointer
 type chtype uint64
-type mmaskt uint64
 
-type CursesError struct {
-	message string
-}
-
-func (ce CursesError) Error() string {
-	return ce.message
-}
+// MouseMask is a set of mouse events, a combination of the BUTTON*
+// constants.
+type MouseMask uint64
 
 const (
 	CURS_HIDE = iota
@@ -51,97 +46,187 @@ func init() {
 }
 
 func Start_color() error {
-	if int(C.has_colors()) == ERR {
-		return CursesError{"terminal does not support color"}
+	CheckThread()
+	if !cbool(C.has_colors()) {
+		return CursesError{"has_colors", ERR}
+	}
+	if C.start_color() == C.ERR {
+		return CursesError{"start_color", ERR}
 	}
-	C.start_color()
-
 	return nil
 }
 
 func Init_pair(pair int, fg int, bg int) error {
+	CheckThread()
 	if C.init_pair(C.short(pair), C.short(fg), C.short(bg)) == ERR {
-		return CursesError{"Init_pair failed"}
+		return CursesError{"init_pair", ERR}
+	}
+	return nil
+}
+
+// Pair_content returns the foreground and background colors of pair.
+func Pair_content(pair int) (int, int, error) {
+	CheckThread()
+	var fg, bg C.short
+	if C.pair_content(C.short(pair), &fg, &bg) == ERR {
+		return 0, 0, CursesError{"pair_content", ERR}
+	}
+	return int(fg), int(bg), nil
+}
+
+// Init_color redefines color with red, green and blue components in the
+// range 0-1000. It only works if Can_change_color reports true.
+func Init_color(color, r, g, b int) error {
+	CheckThread()
+	if C.init_color(C.short(color), C.short(r), C.short(g), C.short(b)) == ERR {
+		return CursesError{"init_color", ERR}
+	}
+	return nil
+}
+
+// Color_content returns the red, green and blue components of color in the
+// range 0-1000.
+func Color_content(color int) (int, int, int, error) {
+	CheckThread()
+	var r, g, b C.short
+	if C.color_content(C.short(color), &r, &g, &b) == ERR {
+		return 0, 0, 0, CursesError{"color_content", ERR}
+	}
+	return int(r), int(g), int(b), nil
+}
+
+func Can_change_color() bool {
+	CheckThread()
+	return cbool(C.can_change_color())
+}
+
+// cbool converts a bool returned by curses. The preamble defines _Bool as
+// int to please cgo, but the library only sets the low byte of the result.
+func cbool(b C.int) bool {
+	return b&0xff != 0
+}
+
+// Use_default_colors lets -1 stand for the terminal's default foreground
+// or background color in Init_pair, e.g. for a transparent background.
+func Use_default_colors() error {
+	CheckThread()
+	if C.use_default_colors() == ERR {
+		return CursesError{"use_default_colors", ERR}
+	}
+	return nil
+}
+
+// Assume_default_colors is like Use_default_colors, but also sets the
+// colors of pair 0 to fg and bg.
+func Assume_default_colors(fg, bg int) error {
+	CheckThread()
+	if C.assume_default_colors(C.int(fg), C.int(bg)) == ERR {
+		return CursesError{"assume_default_colors", ERR}
+	}
+	return nil
+}
+
+// Init_extended_pair is like Init_pair, but accepts pair and color numbers
+// beyond the range of a C short.
+func Init_extended_pair(pair, fg, bg int) error {
+	CheckThread()
+	if C.init_extended_pair(C.int(pair), C.int(fg), C.int(bg)) == ERR {
+		return CursesError{"init_extended_pair", ERR}
+	}
+	return nil
+}
+
+func Extended_pair_content(pair int) (int, int, error) {
+	CheckThread()
+	var fg, bg C.int
+	if C.extended_pair_content(C.int(pair), &fg, &bg) == ERR {
+		return 0, 0, CursesError{"extended_pair_content", ERR}
+	}
+	return int(fg), int(bg), nil
+}
+
+func Init_extended_color(color, r, g, b int) error {
+	CheckThread()
+	if C.init_extended_color(C.int(color), C.int(r), C.int(g), C.int(b)) == ERR {
+		return CursesError{"init_extended_color", ERR}
 	}
 	return nil
 }
 
+func Extended_color_content(color int) (int, int, int, error) {
+	CheckThread()
+	var r, g, b C.int
+	if C.extended_color_content(C.int(color), &r, &g, &b) == ERR {
+		return 0, 0, 0, CursesError{"extended_color_content", ERR}
+	}
+	return int(r), int(g), int(b), nil
+}
+
 func Color_pair(pair int) int {
+	CheckThread()
 	return int(C.COLOR_PAIR(C.int(pair)))
 }
 
 func Beep() error {
+	CheckThread()
 	if int(C.beep()) == ERR {
-		return CursesError{"Beep failed"}
+		return CursesError{"beep", ERR}
 	}
 	return nil
 }
 
 func Noecho() error {
+	CheckThread()
 	if int(C.noecho()) == ERR {
-		return CursesError{"Noecho failed"}
+		return CursesError{"noecho", ERR}
 	}
 	return nil
 }
 
 func DoUpdate() error {
+	CheckThread()
 	if int(C.doupdate()) == ERR {
-		return CursesError{"Doupdate failed"}
+		return CursesError{"doupdate", ERR}
 	}
 	return nil
 }
 
 func Echo() error {
+	CheckThread()
 	if int(C.echo()) == ERR {
-		return CursesError{"Echo failed"}
+		return CursesError{"echo", ERR}
 	}
 	return nil
 }
 
 func Curs_set(c int) error {
+	CheckThread()
 	if C.curs_set(C.int(c)) == ERR {
-		return CursesError{"Curs_set failed"}
+		return CursesError{"curs_set", ERR}
 	}
 	return nil
 }
 
 func Nocbreak() error {
+	CheckThread()
 	if C.nocbreak() == ERR {
-		return CursesError{"Nocbreak failed"}
+		return CursesError{"nocbreak", ERR}
 	}
 	return nil
 }
 
 func Cbreak() error {
+	CheckThread()
 	if C.cbreak() == ERR {
-		return CursesError{"Cbreak failed"}
+		return CursesError{"cbreak", ERR}
 	}
 	return nil
 }
 
 func Endwin() error {
+	CheckThread()
 	if C.endwin() == ERR {
-		return CursesError{"Endwin failed"}
-	}
-	return nil
-}
-
-// Since CGO currently can't handle varg C functions we'll mimic the
-// ncurses addstr functions.
-/*
-func (win *Window) Addstr(x, y int, str string, flags int32, v ...interface{}) {
-	var newstr string
-	if v != nil {
-		newstr = fmt.Sprintf(str, v)
-	} else {
-		newstr = str
-	}
-
-	win.Move(x, y)
-
-	for i := 0; i < len(newstr); i++ {
-		C.waddch((*C.WINDOW)(win), C.chtype(newstr[i])|C.chtype(flags))
+		return CursesError{"endwin", ERR}
 	}
 	return nil
 }
-*/
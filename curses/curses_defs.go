@@ -39,6 +39,7 @@ const (
 	COLOR_MAGENTA = C.COLOR_MAGENTA
 	COLOR_YELLOW  = C.COLOR_YELLOW
 	COLOR_WHITE   = C.COLOR_WHITE
+	KEY_CODE_YES  = C.KEY_CODE_YES
 	KEY_BREAK     = C.KEY_BREAK
 	KEY_DOWN      = C.KEY_DOWN
 	KEY_UP        = C.KEY_UP
@@ -128,4 +129,37 @@ const (
 	KEY_SUNDO     = C.KEY_SUNDO
 	KEY_SUSPEND   = C.KEY_SUSPEND
 	KEY_UNDO      = C.KEY_UNDO
+	KEY_MOUSE     = C.KEY_MOUSE
+	KEY_RESIZE    = C.KEY_RESIZE
+
+	BUTTON1_PRESSED        = C.BUTTON1_PRESSED
+	BUTTON1_RELEASED       = C.BUTTON1_RELEASED
+	BUTTON1_CLICKED        = C.BUTTON1_CLICKED
+	BUTTON1_DOUBLE_CLICKED = C.BUTTON1_DOUBLE_CLICKED
+	BUTTON1_TRIPLE_CLICKED = C.BUTTON1_TRIPLE_CLICKED
+	BUTTON2_PRESSED        = C.BUTTON2_PRESSED
+	BUTTON2_RELEASED       = C.BUTTON2_RELEASED
+	BUTTON2_CLICKED        = C.BUTTON2_CLICKED
+	BUTTON2_DOUBLE_CLICKED = C.BUTTON2_DOUBLE_CLICKED
+	BUTTON2_TRIPLE_CLICKED = C.BUTTON2_TRIPLE_CLICKED
+	BUTTON3_PRESSED        = C.BUTTON3_PRESSED
+	BUTTON3_RELEASED       = C.BUTTON3_RELEASED
+	BUTTON3_CLICKED        = C.BUTTON3_CLICKED
+	BUTTON3_DOUBLE_CLICKED = C.BUTTON3_DOUBLE_CLICKED
+	BUTTON3_TRIPLE_CLICKED = C.BUTTON3_TRIPLE_CLICKED
+	BUTTON4_PRESSED        = C.BUTTON4_PRESSED
+	BUTTON4_RELEASED       = C.BUTTON4_RELEASED
+	BUTTON4_CLICKED        = C.BUTTON4_CLICKED
+	BUTTON4_DOUBLE_CLICKED = C.BUTTON4_DOUBLE_CLICKED
+	BUTTON4_TRIPLE_CLICKED = C.BUTTON4_TRIPLE_CLICKED
+	BUTTON5_PRESSED        = C.BUTTON5_PRESSED
+	BUTTON5_RELEASED       = C.BUTTON5_RELEASED
+	BUTTON5_CLICKED        = C.BUTTON5_CLICKED
+	BUTTON5_DOUBLE_CLICKED = C.BUTTON5_DOUBLE_CLICKED
+	BUTTON5_TRIPLE_CLICKED = C.BUTTON5_TRIPLE_CLICKED
+	BUTTON_CTRL            = C.BUTTON_CTRL
+	BUTTON_SHIFT           = C.BUTTON_SHIFT
+	BUTTON_ALT             = C.BUTTON_ALT
+	REPORT_MOUSE_POSITION  = C.REPORT_MOUSE_POSITION
+	ALL_MOUSE_EVENTS       = C.ALL_MOUSE_EVENTS
 )
@@ -0,0 +1,62 @@
+package curses
+
+// #include <stdint.h>
+// static void *_handle_ptr(uintptr_t h) { return (void *)h; }
+import "C"
+
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+// UserData keeps a Go value for the user pointer of a C object. The
+// pointer holds a cgo.Handle, never a Go pointer, so it is safe to store.
+// The zero UserData holds no value.
+type UserData struct {
+	h cgo.Handle
+}
+
+// Set replaces the value with v, or clears it if v is nil. store puts the
+// new user pointer in the C object. The previous value is released only
+// once store succeeds; if it fails, v is dropped and the previous value
+// kept.
+func (d *UserData) Set(v any, store func(ptr unsafe.Pointer) error) error {
+	var h cgo.Handle
+	var ptr unsafe.Pointer
+	if v != nil {
+		h = cgo.NewHandle(v)
+		ptr = C._handle_ptr(C.uintptr_t(h))
+	}
+	if err := store(ptr); err != nil {
+		if h != 0 {
+			h.Delete()
+		}
+		return err
+	}
+	d.Release()
+	d.h = h
+	return nil
+}
+
+// Get returns the value, given the user pointer currently stored in the C
+// object. If the pointer was changed by other means, Get returns nil.
+func (d *UserData) Get(ptr unsafe.Pointer) any {
+	if d.h == 0 || uintptr(ptr) != uintptr(d.h) {
+		return nil
+	}
+	return d.h.Value()
+}
+
+// Release drops the value.
+func (d *UserData) Release() {
+	if d.h != 0 {
+		d.h.Delete()
+		d.h = 0
+	}
+}
+
+// DataAs returns the value attached to obj with SetData if it has type T.
+func DataAs[T any](obj interface{ Data() any }) (T, bool) {
+	v, ok := obj.Data().(T)
+	return v, ok
+}
@@ -0,0 +1,50 @@
+package curses
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+)
+
+func TestUserData(t *testing.T) {
+	var d UserData
+	var stored unsafe.Pointer
+	store := func(ptr unsafe.Pointer) error {
+		stored = ptr
+		return nil
+	}
+
+	if err := d.Set("one", store); err != nil {
+		t.Fatal(err)
+	}
+	if v := d.Get(stored); v != "one" {
+		t.Errorf("Get = %v, want one", v)
+	}
+	if v := d.Get(nil); v != nil {
+		t.Errorf("Get with another pointer = %v, want nil", v)
+	}
+
+	// A failed store keeps the previous value.
+	failed := errors.New("store failed")
+	err := d.Set("two", func(ptr unsafe.Pointer) error { return failed })
+	if err != failed {
+		t.Errorf("Set = %v, want %v", err, failed)
+	}
+	if v := d.Get(stored); v != "one" {
+		t.Errorf("Get after a failed Set = %v, want one", v)
+	}
+
+	if err := d.Set(nil, store); err != nil {
+		t.Fatal(err)
+	}
+	if stored != nil || d.h != 0 {
+		t.Errorf("Set(nil) stored %p and kept handle %v", stored, d.h)
+	}
+
+	d.Set("three", store)
+	d.Release()
+	d.Release()
+	if d.h != 0 || d.Get(stored) != nil {
+		t.Error("Release kept the value")
+	}
+}
@@ -0,0 +1,188 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+// #include <stdio.h>
+// #include <stdlib.h>
+// #include <unistd.h>
+// static FILE *_fdopen_dup(int fd, const char *mode) {
+// 	int nfd = dup(fd);
+// 	FILE *f;
+// 	if (nfd < 0)
+// 		return NULL;
+// 	if ((f = fdopen(nfd, mode)) == NULL)
+// 		close(nfd);
+// 	return f;
+// }
+import "C"
+
+import (
+	"io"
+	"os"
+	"unsafe"
+)
+
+// Getwin reads a window written by Putwin from file. The C library reads
+// through its own buffer, so the offset of file afterwards may lie past
+// the end of the window data.
+func Getwin(file *os.File) (*Window, error) {
+	CheckThread()
+	mode := C.CString("r")
+	defer C.free(unsafe.Pointer(mode))
+	f := C._fdopen_dup(C.int(file.Fd()), mode)
+	if f == nil {
+		return nil, CursesError{"fdopen", ERR}
+	}
+	defer C.fclose(f)
+	return getwin(f)
+}
+
+// ReadWindow reads a window written by Putwin or WriteTo from r.
+func ReadWindow(r io.Reader) (*Window, error) {
+	CheckThread()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, CursesError{"getwin", ERR}
+	}
+	buf := C.CBytes(data)
+	defer C.free(buf)
+	mode := C.CString("r")
+	defer C.free(unsafe.Pointer(mode))
+	f := C.fmemopen(buf, C.size_t(len(data)), mode)
+	if f == nil {
+		return nil, CursesError{"fmemopen", ERR}
+	}
+	defer C.fclose(f)
+	return getwin(f)
+}
+
+func getwin(f *C.FILE) (*Window, error) {
+	win := (*Window)(C.getwin(f))
+	if win == nil {
+		return nil, CursesError{"getwin", ERR}
+	}
+	return win, nil
+}
+
+// Putwin writes the contents and state of the window to file.
+func (win *Window) Putwin(file *os.File) error {
+	CheckThread()
+	mode := C.CString("w")
+	defer C.free(unsafe.Pointer(mode))
+	f := C._fdopen_dup(C.int(file.Fd()), mode)
+	if f == nil {
+		return CursesError{"fdopen", ERR}
+	}
+	r := C.putwin((*C.WINDOW)(win), f)
+	if C.fclose(f) != 0 || r == C.ERR {
+		return CursesError{"putwin", ERR}
+	}
+	return nil
+}
+
+// WriteTo writes the window in the format of Putwin to w.
+func (win *Window) WriteTo(w io.Writer) (int64, error) {
+	CheckThread()
+	var buf *C.char
+	var size C.size_t
+	f := C.open_memstream(&buf, &size)
+	if f == nil {
+		return 0, CursesError{"open_memstream", ERR}
+	}
+	r := C.putwin((*C.WINDOW)(win), f)
+	C.fclose(f)
+	defer C.free(unsafe.Pointer(buf))
+	if r == C.ERR {
+		return 0, CursesError{"putwin", ERR}
+	}
+	n, err := w.Write(C.GoBytes(unsafe.Pointer(buf), C.int(size)))
+	return int64(n), err
+}
+
+// Scr_dump writes the virtual screen to the named file.
+func Scr_dump(filename string) error {
+	CheckThread()
+	s := C.CString(filename)
+	defer C.free(unsafe.Pointer(s))
+	if C.scr_dump(s) == C.ERR {
+		return CursesError{"scr_dump", ERR}
+	}
+	return nil
+}
+
+// Scr_restore sets the virtual screen to the contents of a file written by
+// Scr_dump. The terminal is updated by the next DoUpdate.
+func Scr_restore(filename string) error {
+	CheckThread()
+	s := C.CString(filename)
+	defer C.free(unsafe.Pointer(s))
+	if C.scr_restore(s) == C.ERR {
+		return CursesError{"scr_restore", ERR}
+	}
+	return nil
+}
+
+// Scr_init tells curses that the terminal shows the contents of a file
+// written by Scr_dump, e.g. after another program has drawn it.
+func Scr_init(filename string) error {
+	CheckThread()
+	s := C.CString(filename)
+	defer C.free(unsafe.Pointer(s))
+	if C.scr_init(s) == C.ERR {
+		return CursesError{"scr_init", ERR}
+	}
+	return nil
+}
+
+// Scr_set combines Scr_restore and Scr_init.
+func Scr_set(filename string) error {
+	CheckThread()
+	s := C.CString(filename)
+	defer C.free(unsafe.Pointer(s))
+	if C.scr_set(s) == C.ERR {
+		return CursesError{"scr_set", ERR}
+	}
+	return nil
+}
+
+// DumpScreen writes the virtual screen to w in the format of Scr_dump.
+func DumpScreen(w io.Writer) error {
+	f, err := os.CreateTemp("", "gocurse")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := Scr_dump(f.Name()); err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// RestoreScreen sets the virtual screen to a dump read from r, as written
+// by DumpScreen. If init is set, curses also assumes the terminal already
+// shows it (see Scr_set).
+func RestoreScreen(r io.Reader, init bool) error {
+	f, err := os.CreateTemp("", "gocurse")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if init {
+		return Scr_set(f.Name())
+	}
+	return Scr_restore(f.Name())
+}
@@ -0,0 +1,178 @@
+package curses_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+)
+
+// drawSample fills a small window with text in several styles. ncurses
+// 6.4 reads a dump back with the attributes of a styled cell carried over
+// to the next one until a plain cell, and with the continuation column of
+// a wide character filled in, so the styled cells are narrow and kept
+// apart.
+func drawSample(t *testing.T, win *curses.Window) {
+	t.Helper()
+	if err := win.MvaddWstr(0, 0, "plain é"); err != nil {
+		t.Fatal(err)
+	}
+	if err := win.MvaddRune(1, 0, 'W', curses.A_BOLD, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := win.MvaddRune(1, 2, 'x', curses.A_UNDERLINE|curses.A_REVERSE, 2); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func windowCells(t *testing.T, win *curses.Window) [][]curses.Cell {
+	t.Helper()
+	rows, _ := win.Getmaxyx()
+	var cells [][]curses.Cell
+	for y := 0; y < rows; y++ {
+		line, err := win.MvinWchstr(y, 0, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cells = append(cells, line)
+	}
+	return cells
+}
+
+func describe(cells [][]curses.Cell) string {
+	var b strings.Builder
+	for _, line := range cells {
+		for _, c := range line {
+			fmt.Fprintf(&b, "%s/%#x/%d ", c, c.Attrs, c.Pair)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func TestWindowRoundTrip(t *testing.T) {
+	term, err := cursestest.New(5, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+	curses.Start_color()
+	curses.Init_pair(1, curses.COLOR_RED, curses.COLOR_BLACK)
+	curses.Init_pair(2, curses.COLOR_GREEN, curses.COLOR_BLUE)
+
+	win, err := curses.Newwin(2, 10, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Del()
+	drawSample(t, win)
+	want := windowCells(t, win)
+
+	t.Run("WriteTo", func(t *testing.T) {
+		var buf bytes.Buffer
+		if _, err := win.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		got, err := curses.ReadWindow(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Del()
+		if cells := windowCells(t, got); !reflect.DeepEqual(cells, want) {
+			t.Errorf("read back %s, want %s", describe(cells), describe(want))
+		}
+	})
+
+	t.Run("Putwin", func(t *testing.T) {
+		f, err := os.Create(filepath.Join(t.TempDir(), "win"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if err := win.Putwin(f); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Seek(0, 0); err != nil {
+			t.Fatal(err)
+		}
+		got, err := curses.Getwin(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer got.Del()
+		if cells := windowCells(t, got); !reflect.DeepEqual(cells, want) {
+			t.Errorf("read back %s, want %s", describe(cells), describe(want))
+		}
+		if y, x := got.Getbegyx(); y != 1 || x != 1 {
+			t.Errorf("window at %d, %d; want 1, 1", y, x)
+		}
+	})
+}
+
+func TestScreenRoundTrip(t *testing.T) {
+	term, err := cursestest.New(4, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+	curses.Start_color()
+	curses.Init_pair(1, curses.COLOR_RED, curses.COLOR_BLACK)
+	curses.Init_pair(2, curses.COLOR_GREEN, curses.COLOR_BLUE)
+	stdscr := term.Stdscr()
+	drawSample(t, stdscr)
+	stdscr.Refresh()
+	want, err := term.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restore := []struct {
+		name string
+		dump func() (func() error, error)
+	}{
+		{"DumpScreen", func() (func() error, error) {
+			var buf bytes.Buffer
+			if err := curses.DumpScreen(&buf); err != nil {
+				return nil, err
+			}
+			return func() error { return curses.RestoreScreen(&buf, false) }, nil
+		}},
+		{"Scr_dump", func() (func() error, error) {
+			name := filepath.Join(t.TempDir(), "screen")
+			if err := curses.Scr_dump(name); err != nil {
+				return nil, err
+			}
+			return func() error { return curses.Scr_restore(name) }, nil
+		}},
+	}
+	for _, tt := range restore {
+		t.Run(tt.name, func(t *testing.T) {
+			restore, err := tt.dump()
+			if err != nil {
+				t.Fatal(err)
+			}
+			stdscr.Erase()
+			stdscr.Refresh()
+			if g, _ := term.Snapshot(); g.String() != "\n\n\n" {
+				t.Fatalf("screen not cleared:\n%s", g)
+			}
+			if err := restore(); err != nil {
+				t.Fatal(err)
+			}
+			curses.DoUpdate()
+			got, err := term.Snapshot()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != want.String() || got.Styles() != want.Styles() {
+				t.Errorf("restored screen:\n%s\n%s\nwant:\n%s\n%s", got, got.Styles(), want, want.Styles())
+			}
+		})
+	}
+}
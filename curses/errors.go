@@ -0,0 +1,109 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+// #include <ncursesw/eti.h>
+import "C"
+
+import "strconv"
+
+// CursesError reports a failed call: the operation, named after the C
+// function that failed, and the status code it returned, either ERR or one
+// of the E_* codes of the menu and form libraries. Use errors.Is with the
+// Err* values to test for a code.
+type CursesError struct {
+	Op   string
+	Code int
+}
+
+// Status codes of the menu and form libraries.
+const (
+	E_OK              = C.E_OK
+	E_SYSTEM_ERROR    = C.E_SYSTEM_ERROR
+	E_BAD_ARGUMENT    = C.E_BAD_ARGUMENT
+	E_POSTED          = C.E_POSTED
+	E_CONNECTED       = C.E_CONNECTED
+	E_BAD_STATE       = C.E_BAD_STATE
+	E_NO_ROOM         = C.E_NO_ROOM
+	E_NOT_POSTED      = C.E_NOT_POSTED
+	E_UNKNOWN_COMMAND = C.E_UNKNOWN_COMMAND
+	E_NO_MATCH        = C.E_NO_MATCH
+	E_NOT_SELECTABLE  = C.E_NOT_SELECTABLE
+	E_NOT_CONNECTED   = C.E_NOT_CONNECTED
+	E_REQUEST_DENIED  = C.E_REQUEST_DENIED
+	E_INVALID_FIELD   = C.E_INVALID_FIELD
+	E_CURRENT         = C.E_CURRENT
+)
+
+// Sentinel errors, one per status code. They match a CursesError of any
+// operation with that code.
+//
+// ErrFailed matches every call that returned a plain ERR, which is how most
+// curses functions fail. E_SYSTEM_ERROR has the same value as ERR, so the
+// system errors of the menu and form libraries cannot be told apart from
+// it and match ErrFailed as well.
+var (
+	ErrFailed         = CursesError{Code: ERR}
+	ErrBadArgument    = CursesError{Code: E_BAD_ARGUMENT}
+	ErrPosted         = CursesError{Code: E_POSTED}
+	ErrConnected      = CursesError{Code: E_CONNECTED}
+	ErrBadState       = CursesError{Code: E_BAD_STATE}
+	ErrNoRoom         = CursesError{Code: E_NO_ROOM}
+	ErrNotPosted      = CursesError{Code: E_NOT_POSTED}
+	ErrUnknownCommand = CursesError{Code: E_UNKNOWN_COMMAND}
+	ErrNoMatch        = CursesError{Code: E_NO_MATCH}
+	ErrNotSelectable  = CursesError{Code: E_NOT_SELECTABLE}
+	ErrNotConnected   = CursesError{Code: E_NOT_CONNECTED}
+	ErrRequestDenied  = CursesError{Code: E_REQUEST_DENIED}
+	ErrInvalidField   = CursesError{Code: E_INVALID_FIELD}
+	ErrCurrent        = CursesError{Code: E_CURRENT}
+)
+
+var statusText = map[int]string{
+	E_BAD_ARGUMENT:    "bad argument",
+	E_POSTED:          "already posted",
+	E_CONNECTED:       "already connected",
+	E_BAD_STATE:       "called from an init or term hook",
+	E_NO_ROOM:         "no room",
+	E_NOT_POSTED:      "not posted",
+	E_UNKNOWN_COMMAND: "unknown command",
+	E_NO_MATCH:        "no match",
+	E_NOT_SELECTABLE:  "not selectable",
+	E_NOT_CONNECTED:   "not connected",
+	E_REQUEST_DENIED:  "request denied",
+	E_INVALID_FIELD:   "invalid field",
+	E_CURRENT:         "is current",
+}
+
+func (ce CursesError) Error() string {
+	op := ce.Op
+	if op == "" {
+		op = "curses"
+	}
+	if ce.Code == ERR {
+		return op + " failed"
+	}
+	text, ok := statusText[ce.Code]
+	if !ok {
+		text = "status " + strconv.Itoa(ce.Code)
+	}
+	return op + ": " + text
+}
+
+// Is reports whether target is a CursesError with the same code and, if
+// target names an operation, the same operation.
+func (ce CursesError) Is(target error) bool {
+	t, ok := target.(CursesError)
+	return ok && t.Code == ce.Code && (t.Op == "" || t.Op == ce.Op)
+}
+
+// CheckStatus returns nil if code is OK, and otherwise a CursesError for
+// op carrying code.
+func CheckStatus(op string, code int) error {
+	if code == OK {
+		return nil
+	}
+	return CursesError{op, code}
+}
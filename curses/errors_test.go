@@ -0,0 +1,64 @@
+package curses
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+)
+
+func TestCursesErrorIs(t *testing.T) {
+	tests := []struct {
+		err    error
+		target error
+		want   bool
+	}{
+		{CursesError{"post_menu", E_POSTED}, ErrPosted, true},
+		{CursesError{"post_menu", E_POSTED}, CursesError{"post_menu", E_POSTED}, true},
+		{CursesError{"post_menu", E_POSTED}, CursesError{"free_menu", E_POSTED}, false},
+		{CursesError{"post_menu", E_POSTED}, ErrNotPosted, false},
+		{CursesError{"wmove", ERR}, ErrFailed, true},
+		{CursesError{"wmove", ERR}, CursesError{"wmove", ERR}, true},
+		{CursesError{"wmove", ERR}, CursesError{"newwin", ERR}, false},
+		{CursesError{"wmove", ERR}, errors.New("wmove failed"), false},
+		// ERR and E_SYSTEM_ERROR are the same code.
+		{CursesError{"set_field_buffer", E_SYSTEM_ERROR}, ErrFailed, true},
+	}
+	for _, tt := range tests {
+		if got := errors.Is(tt.err, tt.target); got != tt.want {
+			t.Errorf("errors.Is(%#v, %#v) = %v, want %v", tt.err, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestCheckStatus(t *testing.T) {
+	if err := CheckStatus("post_menu", OK); err != nil {
+		t.Errorf("CheckStatus(OK) = %v, want nil", err)
+	}
+	err := CheckStatus("post_menu", E_NOT_CONNECTED)
+	var ce CursesError
+	if !errors.As(err, &ce) || ce.Op != "post_menu" || ce.Code != E_NOT_CONNECTED {
+		t.Errorf("CheckStatus(E_NOT_CONNECTED) = %#v", err)
+	}
+	if !errors.Is(err, ErrNotConnected) {
+		t.Errorf("%v does not match ErrNotConnected", err)
+	}
+	if got, want := err.Error(), "post_menu: not connected"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got, want := CheckStatus("wmove", ERR).Error(), "wmove failed"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestNullError(t *testing.T) {
+	err := nullError("newpad", syscall.ENOMEM)
+	if !errors.Is(err, ErrFailed) || !errors.Is(err, CursesError{"newpad", ERR}) {
+		t.Errorf("%v does not match the newpad failure", err)
+	}
+	if !errors.Is(err, syscall.ENOMEM) {
+		t.Errorf("%v does not match ENOMEM", err)
+	}
+	if err := nullError("newpad", nil); err != (CursesError{"newpad", ERR}) {
+		t.Errorf("nullError without errno = %#v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+import "C"
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+type EventType int
+
+const (
+	EventKey    EventType = iota // a function key, in Key
+	EventRune                    // a typed character, in Rune
+	EventMouse                   // a mouse event, in Mouse
+	EventResize                  // a terminal resize, in Size
+	EventTick                    // a timer tick, in Time
+)
+
+// Event is one item of input delivered by Events.
+type Event struct {
+	Type  EventType
+	Key   int
+	Rune  rune
+	Mouse MouseEvent
+	Size  ResizeEvent
+	Time  time.Time
+}
+
+// readWait is how long, in milliseconds, each read on the executor waits
+// for input before letting queued work run.
+const readWait = 10
+
+// Events reads input from win and delivers it on the returned channel,
+// together with resize events for the terminal on stdin and, if tick is
+// positive, a tick every tick. Resize events should be passed to
+// HandleResize.
+//
+// The input is read on the UI executor, so the consumer can keep drawing
+// through the same executor; Events returns ErrNoExecutor if none is
+// running. Each read blocks the executor for up to 10ms while no input
+// arrives, so work posted meanwhile waits that long at most; input itself
+// is delivered as soon as it is read. The channel is closed once ctx is
+// done or the executor is closed, and the input delay of win is then
+// restored.
+func (win *Window) Events(ctx context.Context, tick time.Duration) (<-chan Event, error) {
+	return win.events(ctx, tick, os.Stdin)
+}
+
+// Events is like Window.Events on the session's stdscr, watching the
+// session's own terminal for resizes.
+func (s *Session) Events(ctx context.Context, tick time.Duration) (<-chan Event, error) {
+	return s.stdscr.events(ctx, tick, s.tty)
+}
+
+func (win *Window) events(ctx context.Context, tick time.Duration, tty *os.File) (<-chan Event, error) {
+	e := UI()
+	if e == nil {
+		return nil, ErrNoExecutor
+	}
+	out := make(chan Event)
+	in := make(chan Event)
+
+	go func() {
+		defer close(in)
+		var delay int
+		if !e.Sync(func() {
+			delay = win.Getdelay()
+			win.Timeout(readWait)
+		}) {
+			return
+		}
+		defer e.Sync(func() { win.Timeout(delay) })
+		for ctx.Err() == nil {
+			var ev Event
+			var ok bool
+			if !e.Sync(func() { ev, ok = win.readEvent() }) {
+				return
+			}
+			if !ok {
+				continue
+			}
+			select {
+			case in <- ev:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		// Wait for the input goroutine, so that win is no longer being
+		// read once out is closed.
+		defer func() {
+			for range in {
+			}
+		}()
+
+		resized, stop := WatchResize(tty)
+		defer stop()
+		var ticks <-chan time.Time
+		if tick > 0 {
+			t := time.NewTicker(tick)
+			defer t.Stop()
+			ticks = t.C
+		}
+		for {
+			var ev Event
+			select {
+			case got, ok := <-in:
+				if !ok {
+					return
+				}
+				ev = got
+			case size := <-resized:
+				ev = Event{Type: EventResize, Size: size}
+			case now := <-ticks:
+				ev = Event{Type: EventTick, Time: now}
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case out <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// readEvent waits up to the window's timeout for one input event.
+func (win *Window) readEvent() (Event, bool) {
+	ch, isKey, err := win.GetWch()
+	if err != nil {
+		return Event{}, false
+	}
+	if !isKey {
+		return Event{Type: EventRune, Rune: ch}, true
+	}
+	switch ch {
+	case KEY_MOUSE:
+		me, err := Getmouse()
+		if err != nil {
+			return Event{}, false
+		}
+		return Event{Type: EventMouse, Mouse: *me}, true
+	case KEY_RESIZE:
+		return Event{Type: EventResize, Size: ResizeEvent{int(C.LINES), int(C.COLS)}}, true
+	}
+	return Event{Type: EventKey, Key: int(ch)}, true
+}
@@ -0,0 +1,77 @@
+package curses_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+)
+
+func TestEventsNeedsExecutor(t *testing.T) {
+	if curses.UI() != nil {
+		t.Skip("a UI executor is running")
+	}
+	var win *curses.Window
+	if _, err := win.Events(context.Background(), 0); !errors.Is(err, curses.ErrNoExecutor) {
+		t.Fatalf("Events without executor: got %v, want ErrNoExecutor", err)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	ui := curses.NewExecutor()
+	defer ui.Close()
+
+	var term *cursestest.Terminal
+	var err error
+	ui.Sync(func() {
+		term, err = cursestest.New(5, 20)
+		if err == nil {
+			curses.Cbreak()
+			curses.Noecho()
+			term.Stdscr().Keypad(true)
+			term.Stdscr().Timeout(250)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ui.Sync(func() { term.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events, err := term.Stdscr().Events(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	term.Send("a")
+	if err := term.SendKey(curses.KEY_UP); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []curses.Event{
+		{Type: curses.EventRune, Rune: 'a'},
+		{Type: curses.EventKey, Key: curses.KEY_UP},
+	}
+	for _, w := range want {
+		select {
+		case ev := <-events:
+			if ev.Type != w.Type || ev.Rune != w.Rune || ev.Key != w.Key {
+				t.Errorf("got event %+v, want %+v", ev, w)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for %+v", w)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+	var delay int
+	ui.Sync(func() { delay = term.Stdscr().Getdelay() })
+	if delay != 250 {
+		t.Errorf("delay after Events = %d, want 250", delay)
+	}
+}
@@ -0,0 +1,168 @@
+package curses
+
+// #include <pthread.h>
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Executor runs functions on a single locked OS thread. ncurses is not
+// thread-safe, so once several goroutines are involved every call into
+// curses, panels, menus and forms should be made through Post or Sync.
+//
+// Using the executor is opt-in: the bindings do not route themselves
+// through it. With SetThreadCheck on, they panic when called from any
+// other thread, which finds the calls that were missed.
+type Executor struct {
+	mu     sync.Mutex
+	queue  []func()
+	closed bool
+	wake   chan struct{}
+	done   chan struct{}
+	thread C.pthread_t
+}
+
+// ErrNoExecutor is returned by functions that need a UI executor when none
+// is running.
+var ErrNoExecutor = errors.New("curses: no UI executor running")
+
+var (
+	uiExecutor  atomic.Pointer[Executor]
+	threadCheck atomic.Bool
+)
+
+// NewExecutor starts an executor on a new locked OS thread. The first
+// executor started becomes the UI executor returned by UI, which is the
+// thread SetThreadCheck compares against.
+func NewExecutor() *Executor {
+	e := &Executor{
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	started := make(chan struct{})
+	go e.loop(started)
+	<-started
+	uiExecutor.CompareAndSwap(nil, e)
+	return e
+}
+
+func (e *Executor) loop(started chan<- struct{}) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	defer close(e.done)
+
+	e.thread = C.pthread_self()
+	close(started)
+	for {
+		e.mu.Lock()
+		queue, closed := e.queue, e.closed
+		e.queue = nil
+		e.mu.Unlock()
+		for _, f := range queue {
+			f()
+		}
+		if len(queue) > 0 {
+			continue
+		}
+		if closed {
+			return
+		}
+		<-e.wake
+	}
+}
+
+// UI returns the UI executor, or nil if none is running.
+func UI() *Executor {
+	return uiExecutor.Load()
+}
+
+// Post queues f to run on the executor thread and returns immediately. It
+// never blocks, so it may be called from the executor thread as well. It
+// reports false, and drops f, if the executor has been closed.
+func (e *Executor) Post(f func()) bool {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return false
+	}
+	e.queue = append(e.queue, f)
+	e.mu.Unlock()
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// Sync runs f on the executor thread and waits for it to return. Called
+// from the executor thread itself, it runs f directly. A panic in f is
+// re-raised in the caller. It reports false, without running f, if the
+// executor has been closed.
+func (e *Executor) Sync(f func()) bool {
+	e.mu.Lock()
+	closed := e.closed
+	e.mu.Unlock()
+	if closed {
+		return false
+	}
+	if e.onThread() {
+		f()
+		return true
+	}
+	var p any
+	done := make(chan struct{})
+	ok := e.Post(func() {
+		defer close(done)
+		defer func() { p = recover() }()
+		f()
+	})
+	if !ok {
+		return false
+	}
+	<-done
+	if p != nil {
+		panic(p)
+	}
+	return true
+}
+
+// Close stops the executor once the functions already posted have run.
+// It stops being the UI executor immediately, and later calls to Post and
+// Sync do nothing. Close must not be called from the executor thread.
+func (e *Executor) Close() {
+	uiExecutor.CompareAndSwap(e, nil)
+	e.mu.Lock()
+	e.closed = true
+	e.mu.Unlock()
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+	<-e.done
+}
+
+func (e *Executor) onThread() bool {
+	return C.pthread_equal(C.pthread_self(), e.thread) != 0
+}
+
+// SetThreadCheck turns the thread check made by every binding on or off.
+// While on and a UI executor is running, a curses call made from any other
+// thread panics. It is meant for debugging, as each check costs a cgo call.
+func SetThreadCheck(on bool) {
+	threadCheck.Store(on)
+}
+
+// CheckThread panics if the thread check is on and the caller is not on
+// the UI executor thread. The bindings call it on entry.
+func CheckThread() {
+	if !threadCheck.Load() {
+		return
+	}
+	if e := uiExecutor.Load(); e != nil && !e.onThread() {
+		panic("curses: called off the UI thread")
+	}
+}
@@ -0,0 +1,64 @@
+package curses
+
+import "testing"
+
+func TestExecutorPostFromThread(t *testing.T) {
+	e := NewExecutor()
+	defer e.Close()
+
+	// Posting from the executor thread must not block, however many
+	// functions are queued.
+	n := 0
+	e.Sync(func() {
+		for i := 0; i < 1000; i++ {
+			e.Post(func() { n++ })
+		}
+	})
+	e.Sync(func() {})
+	if n != 1000 {
+		t.Errorf("ran %d posted functions, want 1000", n)
+	}
+}
+
+func TestExecutorSyncInline(t *testing.T) {
+	e := NewExecutor()
+	defer e.Close()
+
+	ran := false
+	e.Sync(func() {
+		e.Sync(func() { ran = e.onThread() })
+	})
+	if !ran {
+		t.Error("nested Sync did not run on the executor thread")
+	}
+}
+
+func TestExecutorClosed(t *testing.T) {
+	e := NewExecutor()
+	if UI() != e {
+		t.Fatal("first executor is not the UI executor")
+	}
+	e.Close()
+	if UI() != nil {
+		t.Error("UI executor still set after Close")
+	}
+	if e.Post(func() { t.Error("posted function ran after Close") }) {
+		t.Error("Post reported success after Close")
+	}
+	if e.Sync(func() { t.Error("synced function ran after Close") }) {
+		t.Error("Sync reported success after Close")
+	}
+	e.Close()
+}
+
+func TestExecutorSyncPanic(t *testing.T) {
+	e := NewExecutor()
+	defer e.Close()
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("recovered %v, want boom", p)
+		}
+	}()
+	e.Sync(func() { panic("boom") })
+}
@@ -0,0 +1,22 @@
+package curses
+
+// Rect is a rectangle given by its top left corner and its size.
+type Rect struct {
+	Y, X       int
+	Rows, Cols int
+}
+
+// Point is a position given by row and column.
+type Point struct {
+	Y, X int
+}
+
+func clamp(v, min, max int) int {
+	if v > max {
+		v = max
+	}
+	if v < min {
+		v = min
+	}
+	return v
+}
@@ -0,0 +1,64 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+// #include <stdlib.h>
+import "C"
+
+import "unsafe"
+
+// KeyName returns a printable name for a key as returned by Getch, such as
+// "KEY_PPAGE", "^D" or "a". It returns "" for unknown values.
+func KeyName(key int) string {
+	CheckThread()
+	return C.GoString(C.keyname(C.int(key)))
+}
+
+// Unctrl returns a printable form of a character, showing control
+// characters as e.g. "^C".
+func Unctrl(ch int) string {
+	CheckThread()
+	return C.GoString(C.unctrl(C.chtype(ch)))
+}
+
+// HasKey reports whether the terminal recognizes the KEY_* code key.
+func HasKey(key int) bool {
+	CheckThread()
+	return C.has_key(C.int(key)) != C.FALSE
+}
+
+// KeyDefined returns the key code bound to the escape sequence def, 0 if
+// there is none, or -1 if def is a prefix of a longer bound sequence.
+func KeyDefined(def string) int {
+	CheckThread()
+	s := C.CString(def)
+	defer C.free(unsafe.Pointer(s))
+	return int(C.key_defined(s))
+}
+
+// DefineKey binds the escape sequence def to the key code key, so Getch
+// reports key when the terminal sends def. An empty def removes all
+// bindings for key.
+func DefineKey(def string, key int) error {
+	CheckThread()
+	var s *C.char
+	if def != "" {
+		s = C.CString(def)
+		defer C.free(unsafe.Pointer(s))
+	}
+	if C.define_key(s, C.int(key)) == C.ERR {
+		return CursesError{"define_key", ERR}
+	}
+	return nil
+}
+
+// Keyok enables or disables the recognition of key.
+func Keyok(key int, enable bool) error {
+	CheckThread()
+	if C.keyok(C.int(key), bool2cint(enable)) == C.ERR {
+		return CursesError{"keyok", ERR}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+import "C"
+
+// MouseEvent is a mouse event as reported by Getmouse after Getch returns
+// KEY_MOUSE. Bstate is a combination of the BUTTON* constants.
+type MouseEvent struct {
+	Id      int16
+	X, Y, Z int
+	Bstate  MouseMask
+}
+
+// Mousemask selects the mouse events to be reported and returns the mask of
+// events that can actually be reported, along with the previous mask.
+func Mousemask(newmask MouseMask) (MouseMask, MouseMask, error) {
+	CheckThread()
+	var old C.mmask_t
+	avail := C.mousemask(C.mmask_t(newmask), &old)
+	if avail == 0 && newmask != 0 {
+		return 0, MouseMask(old), CursesError{"mousemask", ERR}
+	}
+	return MouseMask(avail), MouseMask(old), nil
+}
+
+func HasMouse() bool {
+	CheckThread()
+	return cbool(C.has_mouse())
+}
+
+// Mouseinterval sets the maximum time in milliseconds between press and
+// release for them to be reported as a click. It returns the previous value.
+func Mouseinterval(ms int) int {
+	CheckThread()
+	return int(C.mouseinterval(C.int(ms)))
+}
+
+func Getmouse() (*MouseEvent, error) {
+	CheckThread()
+	var ev C.MEVENT
+	if C.getmouse(&ev) == C.ERR {
+		return nil, CursesError{"getmouse", ERR}
+	}
+	return &MouseEvent{
+		Id:     int16(ev.id),
+		X:      int(ev.x),
+		Y:      int(ev.y),
+		Z:      int(ev.z),
+		Bstate: MouseMask(ev.bstate),
+	}, nil
+}
+
+func Ungetmouse(me *MouseEvent) error {
+	CheckThread()
+	ev := C.MEVENT{
+		id:     C.short(me.Id),
+		x:      C.int(me.X),
+		y:      C.int(me.Y),
+		z:      C.int(me.Z),
+		bstate: C.mmask_t(me.Bstate),
+	}
+	if C.ungetmouse(&ev) == C.ERR {
+		return CursesError{"ungetmouse", ERR}
+	}
+	return nil
+}
+
+// MouseTrafo converts between screen-relative and window-relative
+// coordinates. The last result is false if the position is outside win.
+func (win *Window) MouseTrafo(y, x int, toScreen bool) (int, int, bool) {
+	CheckThread()
+	cy, cx := C.int(y), C.int(x)
+	if !cbool(C.wmouse_trafo((*C.WINDOW)(win), &cy, &cx, bool2cint(toScreen))) {
+		return y, x, false
+	}
+	return int(cy), int(cx), true
+}
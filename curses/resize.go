@@ -0,0 +1,165 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+// #include <sys/ioctl.h>
+// static int _term_size(int fd, int *rows, int *cols) {
+// 	struct winsize ws;
+// 	if (ioctl(fd, TIOCGWINSZ, &ws) < 0)
+// 		return -1;
+// 	*rows = ws.ws_row;
+// 	*cols = ws.ws_col;
+// 	return 0;
+// }
+import "C"
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// ResizeEvent reports the size of the terminal after a resize.
+type ResizeEvent struct {
+	Rows, Cols int
+}
+
+func Resizeterm(rows, cols int) error {
+	CheckThread()
+	if C.resizeterm(C.int(rows), C.int(cols)) == C.ERR {
+		return CursesError{"resizeterm", ERR}
+	}
+	return nil
+}
+
+func Resize_term(rows, cols int) error {
+	CheckThread()
+	if C.resize_term(C.int(rows), C.int(cols)) == C.ERR {
+		return CursesError{"resize_term", ERR}
+	}
+	return nil
+}
+
+func Is_term_resized(rows, cols int) bool {
+	CheckThread()
+	return cbool(C.is_term_resized(C.int(rows), C.int(cols)))
+}
+
+// TermSize asks the terminal connected to f for its current size.
+func TermSize(f *os.File) (ResizeEvent, error) {
+	var rows, cols C.int
+	if C._term_size(C.int(f.Fd()), &rows, &cols) != 0 {
+		return ResizeEvent{}, CursesError{"ioctl", ERR}
+	}
+	return ResizeEvent{int(rows), int(cols)}, nil
+}
+
+type resizeHandler struct {
+	f func(ResizeEvent) error
+}
+
+var resizeHandlers struct {
+	sync.Mutex
+	list []*resizeHandler
+}
+
+// OnResize registers f to be called by HandleResize with the new size.
+// Handlers run in registration order; the returned function unregisters f.
+func OnResize(f func(ResizeEvent) error) (cancel func()) {
+	h := &resizeHandler{f}
+	resizeHandlers.Lock()
+	resizeHandlers.list = append(resizeHandlers.list, h)
+	resizeHandlers.Unlock()
+	return func() {
+		resizeHandlers.Lock()
+		defer resizeHandlers.Unlock()
+		for i, e := range resizeHandlers.list {
+			if e == h {
+				resizeHandlers.list = append(resizeHandlers.list[:i], resizeHandlers.list[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// OnResize registers layout to compute the window's new position and size
+// whenever the terminal is resized. The window is resized and then moved
+// to the returned rectangle.
+func (win *Window) OnResize(layout func(ResizeEvent) Rect) (cancel func()) {
+	return OnResize(func(ev ResizeEvent) error {
+		r := layout(ev)
+		if err := win.Resize(r.Rows, r.Cols); err != nil {
+			return err
+		}
+		return win.Mvwin(r.Y, r.X)
+	})
+}
+
+// HandleResize resizes curses to ev, unless it already has that size, and
+// then calls every registered handler with the resulting size. It should be
+// called from the goroutine driving curses, either after Getch returns
+// KEY_RESIZE or for each event received from WatchResize. All handlers are
+// called; the first error is returned.
+func HandleResize(ev ResizeEvent) error {
+	CheckThread()
+	if Is_term_resized(ev.Rows, ev.Cols) {
+		if err := Resizeterm(ev.Rows, ev.Cols); err != nil {
+			return err
+		}
+	}
+	ev = ResizeEvent{int(C.LINES), int(C.COLS)}
+
+	resizeHandlers.Lock()
+	list := append([]*resizeHandler(nil), resizeHandlers.list...)
+	resizeHandlers.Unlock()
+
+	var first error
+	for _, h := range list {
+		if err := h.f(ev); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// WatchResize relays SIGWINCH as events carrying the size of the terminal
+// connected to f. The Go runtime owns the signal, so ncurses never installs
+// its own handler and Getch will not report KEY_RESIZE by itself; pass the
+// events to HandleResize instead. Call stop to release the signal.
+func WatchResize(f *os.File) (events <-chan ResizeEvent, stop func()) {
+	sigs := make(chan os.Signal, 1)
+	out := make(chan ResizeEvent, 1)
+	done := make(chan struct{})
+	signal.Notify(sigs, syscall.SIGWINCH)
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-sigs:
+			case <-done:
+				return
+			}
+			ev, err := TermSize(f)
+			if err != nil {
+				continue
+			}
+			select {
+			case out <- ev:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return out, func() {
+		once.Do(func() {
+			signal.Stop(sigs)
+			close(done)
+		})
+	}
+}
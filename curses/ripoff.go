@@ -0,0 +1,170 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+// extern int goRipoffInit(WINDOW *win, int cols);
+import "C"
+
+import "sync"
+
+// ncurses calls the ripoffline callbacks during Initscr in the order they
+// were registered, without any user data, so the Go side keeps them in a
+// queue and hands each call to the next one.
+var ripoffs struct {
+	sync.Mutex
+	pending []func(win *Window, cols int) error
+}
+
+//export goRipoffInit
+func goRipoffInit(win *C.WINDOW, cols C.int) C.int {
+	ripoffs.Lock()
+	if len(ripoffs.pending) == 0 {
+		ripoffs.Unlock()
+		return C.ERR
+	}
+	init := ripoffs.pending[0]
+	ripoffs.pending = ripoffs.pending[1:]
+	ripoffs.Unlock()
+
+	if init((*Window)(win), int(cols)) != nil {
+		return C.ERR
+	}
+	return C.OK
+}
+
+// Ripoffline reserves a line at the top of the screen if line is positive,
+// or at the bottom if it is negative. It must be called before Initscr or
+// Newterm, which call init with a one-line window spanning cols columns.
+// Up to five lines can be reserved.
+func Ripoffline(line int, init func(win *Window, cols int) error) error {
+	CheckThread()
+	if C.stdscr != nil {
+		return CursesError{"ripoffline", ERR}
+	}
+	ripoffs.Lock()
+	ripoffs.pending = append(ripoffs.pending, init)
+	ripoffs.Unlock()
+	if C.ripoffline(C.int(line), (*[0]byte)(C.goRipoffInit)) == C.ERR {
+		ripoffs.Lock()
+		ripoffs.pending = ripoffs.pending[:len(ripoffs.pending)-1]
+		ripoffs.Unlock()
+		return CursesError{"ripoffline", ERR}
+	}
+	return nil
+}
+
+// ripLine is a line ripped off the screen that keeps a text and redraws it
+// after the terminal is resized.
+type ripLine struct {
+	win    *Window
+	text   string
+	attrs  int
+	pair   int16
+	cancel func()
+}
+
+// StatusLine owns a line ripped off the bottom of the screen.
+type StatusLine struct {
+	ripLine
+}
+
+// TitleLine owns a line ripped off the top of the screen.
+type TitleLine struct {
+	ripLine
+}
+
+// NewStatusLine reserves the bottom line of the screen. Like Ripoffline, it
+// must be called before Initscr.
+func NewStatusLine() (*StatusLine, error) {
+	l := new(StatusLine)
+	if err := l.ripoff(-1); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// NewTitleLine reserves the top line of the screen. Like Ripoffline, it
+// must be called before Initscr.
+func NewTitleLine() (*TitleLine, error) {
+	l := new(TitleLine)
+	if err := l.ripoff(1); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *ripLine) ripoff(line int) error {
+	err := Ripoffline(line, func(win *Window, cols int) error {
+		l.win = win
+		return l.draw()
+	})
+	if err != nil {
+		return err
+	}
+	l.cancel = OnResize(func(ResizeEvent) error {
+		return l.Noutrefresh()
+	})
+	return nil
+}
+
+// Window returns the ripped-off window, or nil before Initscr.
+func (l *ripLine) Window() *Window {
+	return l.win
+}
+
+func (l *ripLine) Text() string {
+	return l.text
+}
+
+// Set replaces the text of the line. Text that does not fit is cut off.
+// The change shows on the next Refresh or Noutrefresh.
+func (l *ripLine) Set(text string) error {
+	l.text = text
+	return l.draw()
+}
+
+// SetAttr sets the attributes and color pair of the whole line.
+func (l *ripLine) SetAttr(attrs int, pair int16) error {
+	l.attrs, l.pair = attrs, pair
+	return l.draw()
+}
+
+func (l *ripLine) Refresh() error {
+	if err := l.draw(); err != nil {
+		return err
+	}
+	return l.win.Refresh()
+}
+
+func (l *ripLine) Noutrefresh() error {
+	if err := l.draw(); err != nil {
+		return err
+	}
+	return l.win.Noutrefresh()
+}
+
+// Close stops the line from following resizes.
+func (l *ripLine) Close() {
+	if l.cancel != nil {
+		l.cancel()
+		l.cancel = nil
+	}
+}
+
+func (l *ripLine) draw() error {
+	if l.win == nil {
+		return nil
+	}
+	l.win.Bkgdset(chtype(' ') | chtype(l.attrs) | chtype(Color_pair(int(l.pair))))
+	if err := l.win.Erase(); err != nil {
+		return err
+	}
+	if err := l.win.Move(0, 0); err != nil {
+		return err
+	}
+	// Inserting rather than adding never wraps the cursor past the end of
+	// the line, so text filling the whole width does not fail.
+	return l.win.InsWstr(l.text)
+}
@@ -0,0 +1,75 @@
+package curses_test
+
+import (
+	"testing"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+)
+
+func TestRipoffLines(t *testing.T) {
+	const statusText = "status 0123456789abcdefghij"
+
+	title, err := curses.NewTitleLine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer title.Close()
+	status, err := curses.NewStatusLine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer status.Close()
+	if title.Window() != nil || status.Window() != nil {
+		t.Fatal("ripped-off windows exist before the screen")
+	}
+
+	term, err := cursestest.New(6, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+	if title.Window() == nil || status.Window() == nil {
+		t.Fatal("ripped-off windows not created by the screen")
+	}
+	stdscr := term.Stdscr()
+
+	check := func(rows, cols int) {
+		t.Helper()
+		if y, x := stdscr.Getmaxyx(); y != rows-2 || x != cols {
+			t.Errorf("stdscr is %dx%d, want %dx%d", y, x, rows-2, cols)
+		}
+		stdscr.Erase()
+		stdscr.MvaddWstr(0, 0, "body")
+		stdscr.Noutrefresh()
+		curses.DoUpdate()
+		g, err := term.Snapshot()
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := map[int]string{0: "title", 1: "body", rows - 1: statusText[:cols]}
+		for y, want := range lines {
+			if got := g.Line(y); got != want {
+				t.Errorf("line %d = %q, want %q\n%s", y, got, want, g)
+			}
+		}
+	}
+
+	// The status text is wider than the screen, so that it only shows in
+	// full once the line is redrawn at the new width.
+	title.Set("title")
+	status.Set(statusText)
+	title.Noutrefresh()
+	status.Noutrefresh()
+	check(6, 20)
+
+	// HandleResize, called by Resize, redraws the lines through OnResize.
+	if err := term.Resize(9, 24); err != nil {
+		t.Fatal(err)
+	}
+	check(9, 24)
+	if err := term.Resize(5, 12); err != nil {
+		t.Fatal(err)
+	}
+	check(5, 12)
+}
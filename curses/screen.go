@@ -13,38 +13,55 @@ import (
 
 type Screen C.SCREEN
 
+// The standard, virtual and physical screens of the current terminal.
+// They are refreshed whenever a terminal is started or switched to.
 var (
 	Stdscr = C.stdscr
 	Newscr = C.newscr
 	Curscr = C.curscr
 )
 
+// syncGlobals refreshes Stdwin, Stdscr, Newscr and Curscr from the current
+// terminal.
+func syncGlobals() {
+	Stdscr, Newscr, Curscr = C.stdscr, C.newscr, C.curscr
+	Stdwin = (*Window)(C.stdscr)
+}
+
 func Newterm(s string, out, in *os.File) (*Screen, error) {
+	CheckThread()
 	cs := C.CString(s)
 	defer C.free(unsafe.Pointer(cs))
 	oFile, iFile := C.fdopen(C.int(out.Fd()), C._wplus_), C.fdopen(C.int(in.Fd()), C._rplus_)
 	screen := (*Screen)(C.newterm(cs, oFile, iFile))
 	if screen == nil {
-		return nil, CursesError{"Failed to create term"}
+		return nil, CursesError{"newterm", ERR}
 	}
+	syncGlobals()
 	return screen, nil
 }
 
 func (scr *Screen) DelScreen() {
+	CheckThread()
 	C.delscreen((*C.SCREEN)(scr))
 }
 
 func (scr *Screen) SetTerm() *Screen {
+	CheckThread()
 	ret := C.set_term((*C.SCREEN)(scr))
+	syncGlobals()
 	return (*Screen)(ret)
 }
 
 func DelScreen(scr *Screen) {
+	CheckThread()
 	C.delscreen((*C.SCREEN)(scr))
 }
 
 func SetTerm(scr *Screen) *Screen {
+	CheckThread()
 	ret := C.set_term((*C.SCREEN)(scr))
+	syncGlobals()
 	return (*Screen)(ret)
 }
 
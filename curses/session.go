@@ -0,0 +1,153 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+// #include <stdlib.h>
+// #include <locale.h>
+import "C"
+
+import (
+	"os"
+	"unsafe"
+)
+
+// Options selects the terminal modes a Session is set up with.
+type Options struct {
+	Cbreak     bool // deliver keys as they are typed
+	Noecho     bool // do not echo typed characters
+	Keypad     bool // decode function keys on stdscr into KEY_* codes
+	HideCursor bool
+	Colors     bool // start color if the terminal has it
+}
+
+// Session is a running curses terminal. It owns its stdscr and, for
+// sessions created by NewSession, its SCREEN.
+type Session struct {
+	screen *Screen
+	stdscr *Window
+	tty    *os.File
+	closed bool
+}
+
+// Init starts curses on the process terminal, like Initscr, and applies
+// opts.
+func Init(opts Options) (*Session, error) {
+	win, err := Initscr()
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{stdscr: win, tty: os.Stdout}
+	if err := s.Setup(opts); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewSession starts curses on a terminal of the given type reading from in
+// and writing to out. An empty term uses $TERM. The new session becomes
+// the current terminal; call Setup to choose its modes.
+func NewSession(term string, in, out *os.File) (*Session, error) {
+	CheckThread()
+	empty := C.CString("")
+	defer C.free(unsafe.Pointer(empty))
+	C.setlocale(C.LC_ALL, empty)
+
+	if term == "" {
+		term = os.Getenv("TERM")
+	}
+	scr, err := Newterm(term, out, in)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{screen: scr, stdscr: (*Window)(C.stdscr), tty: out}, nil
+}
+
+// Setup applies opts to the session, making it current first.
+func (s *Session) Setup(opts Options) error {
+	CheckThread()
+	s.Use()
+	if opts.Cbreak {
+		if err := Cbreak(); err != nil {
+			return err
+		}
+	}
+	if opts.Noecho {
+		if err := Noecho(); err != nil {
+			return err
+		}
+	}
+	if opts.Keypad {
+		if err := s.stdscr.Keypad(true); err != nil {
+			return err
+		}
+	}
+	if opts.HideCursor {
+		// Not every terminal can hide the cursor; that is not fatal.
+		Curs_set(0)
+	}
+	if opts.Colors && cbool(C.has_colors()) {
+		if err := Start_color(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Use makes s the current terminal. It only matters when several sessions
+// were created with NewSession.
+func (s *Session) Use() {
+	if s.screen != nil {
+		s.screen.SetTerm()
+	}
+}
+
+// Screen returns the SCREEN of a session created by NewSession, or nil.
+func (s *Session) Screen() *Screen {
+	return s.screen
+}
+
+func (s *Session) Stdscr() *Window {
+	return s.stdscr
+}
+
+// Size returns the current dimensions of the session's stdscr.
+func (s *Session) Size() (rows, cols int) {
+	return s.stdscr.Getmaxyx()
+}
+
+// Close restores the terminal and, for NewSession sessions, frees the
+// SCREEN. It is safe to call more than once.
+func (s *Session) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.Use()
+	err := Endwin()
+	if s.screen != nil {
+		s.screen.DelScreen()
+		s.screen = nil
+		syncGlobals()
+	}
+	return err
+}
+
+// Run calls f and closes the session when it returns. If f panics, the
+// terminal is restored before the panic continues, so the trace is
+// readable.
+func (s *Session) Run(f func(s *Session) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.Close()
+			panic(r)
+		}
+	}()
+	err = f(s)
+	if cerr := s.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+// #include <stdlib.h>
+import "C"
+
+import "unsafe"
+
+// Soft label layouts for Slk_init.
+const (
+	SLK_323       = 0 // 8 labels arranged 3-2-3
+	SLK_44        = 1 // 8 labels arranged 4-4
+	SLK_444       = 2 // 12 labels arranged 4-4-4
+	SLK_444_INDEX = 3 // 12 labels arranged 4-4-4, with an index line
+)
+
+// Justification of a soft label for Slk_set.
+const (
+	SLK_LEFT   = 0
+	SLK_CENTER = 1
+	SLK_RIGHT  = 2
+)
+
+// Slk_init enables soft function key labels with the given layout. It must
+// be called before Initscr or Newterm, which then take the bottom line (two
+// lines for SLK_444_INDEX) of the screen for the labels.
+func Slk_init(layout int) error {
+	CheckThread()
+	if C.stdscr != nil {
+		return CursesError{"slk_init", ERR}
+	}
+	if C.slk_init(C.int(layout)) == C.ERR {
+		return CursesError{"slk_init", ERR}
+	}
+	return nil
+}
+
+// Slk_set sets the text of label labnum, counting from 1, justified by one
+// of SLK_LEFT, SLK_CENTER or SLK_RIGHT.
+func Slk_set(labnum int, label string, justify int) error {
+	CheckThread()
+	s := C.CString(label)
+	defer C.free(unsafe.Pointer(s))
+	if C.slk_set(C.int(labnum), s, C.int(justify)) == C.ERR {
+		return CursesError{"slk_set", ERR}
+	}
+	return nil
+}
+
+func Slk_label(labnum int) string {
+	CheckThread()
+	return C.GoString(C.slk_label(C.int(labnum)))
+}
+
+func Slk_refresh() error {
+	CheckThread()
+	if C.slk_refresh() == C.ERR {
+		return CursesError{"slk_refresh", ERR}
+	}
+	return nil
+}
+
+func Slk_noutrefresh() error {
+	CheckThread()
+	if C.slk_noutrefresh() == C.ERR {
+		return CursesError{"slk_noutrefresh", ERR}
+	}
+	return nil
+}
+
+func Slk_clear() error {
+	CheckThread()
+	if C.slk_clear() == C.ERR {
+		return CursesError{"slk_clear", ERR}
+	}
+	return nil
+}
+
+func Slk_restore() error {
+	CheckThread()
+	if C.slk_restore() == C.ERR {
+		return CursesError{"slk_restore", ERR}
+	}
+	return nil
+}
+
+func Slk_touch() error {
+	CheckThread()
+	if C.slk_touch() == C.ERR {
+		return CursesError{"slk_touch", ERR}
+	}
+	return nil
+}
+
+func Slk_attron(attrs int) error {
+	CheckThread()
+	if C.slk_attron(C.chtype(attrs)) == C.ERR {
+		return CursesError{"slk_attron", ERR}
+	}
+	return nil
+}
+
+func Slk_attroff(attrs int) error {
+	CheckThread()
+	if C.slk_attroff(C.chtype(attrs)) == C.ERR {
+		return CursesError{"slk_attroff", ERR}
+	}
+	return nil
+}
+
+func Slk_attrset(attrs int) error {
+	CheckThread()
+	if C.slk_attrset(C.chtype(attrs)) == C.ERR {
+		return CursesError{"slk_attrset", ERR}
+	}
+	return nil
+}
+
+func Slk_attr() int {
+	CheckThread()
+	return int(C.slk_attr())
+}
+
+func Slk_color(pair int16) error {
+	CheckThread()
+	if C.slk_color(C.short(pair)) == C.ERR {
+		return CursesError{"slk_color", ERR}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package curses
+
+// Viewport displays part of a pad in a rectangle of the screen and keeps
+// track of the scroll offset, so content larger than the terminal can be
+// paged through.
+type Viewport struct {
+	pad  *Window
+	rect Rect
+	y, x int
+}
+
+// NewViewport creates a pad of padRows by padCols shown in the screen
+// rectangle rect.
+func NewViewport(padRows, padCols int, rect Rect) (*Viewport, error) {
+	pad, err := Newpad(padRows, padCols)
+	if err != nil {
+		return nil, err
+	}
+	return &Viewport{pad: pad, rect: rect}, nil
+}
+
+// Pad returns the pad to draw the content into.
+func (vp *Viewport) Pad() *Window {
+	return vp.pad
+}
+
+func (vp *Viewport) Rect() Rect {
+	return vp.rect
+}
+
+// SetRect moves the viewport to a new screen rectangle, keeping the scroll
+// offset within the pad.
+func (vp *Viewport) SetRect(rect Rect) {
+	vp.rect = rect
+	vp.ScrollTo(vp.y, vp.x)
+}
+
+// Offset returns the pad row and column shown at the top left corner.
+func (vp *Viewport) Offset() (int, int) {
+	return vp.y, vp.x
+}
+
+// ScrollTo sets the scroll offset, clamped so the viewport stays inside
+// the pad.
+func (vp *Viewport) ScrollTo(y, x int) {
+	rows, cols := vp.pad.Getmaxyx()
+	vp.y = clamp(y, 0, rows-vp.rect.Rows)
+	vp.x = clamp(x, 0, cols-vp.rect.Cols)
+}
+
+// Scroll moves the scroll offset by dy rows and dx columns.
+func (vp *Viewport) Scroll(dy, dx int) {
+	vp.ScrollTo(vp.y+dy, vp.x+dx)
+}
+
+func (vp *Viewport) Refresh() error {
+	r := vp.rect
+	return vp.pad.Prefresh(vp.y, vp.x, r.Y, r.X, r.Y+r.Rows-1, r.X+r.Cols-1)
+}
+
+func (vp *Viewport) Noutrefresh() error {
+	r := vp.rect
+	return vp.pad.Pnoutrefresh(vp.y, vp.x, r.Y, r.X, r.Y+r.Rows-1, r.X+r.Cols-1)
+}
+
+// Del deletes the underlying pad.
+func (vp *Viewport) Del() error {
+	return vp.pad.Del()
+}
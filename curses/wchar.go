@@ -0,0 +1,155 @@
+package curses
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+import "C"
+
+import "unsafe"
+
+// Cchar is a complex character: one spacing character, optionally followed
+// by combining characters, together with its attributes and color pair.
+type Cchar C.cchar_t
+
+// NewCchar builds a Cchar from s, which must hold a single spacing character
+// and at most CCHARW_MAX-1 combining characters.
+func NewCchar(s string, attrs int, pair int16) (*Cchar, error) {
+	CheckThread()
+	c := new(Cchar)
+	ws := wcstr(s)
+	if C.setcchar((*C.cchar_t)(c), &ws[0], C.attr_t(attrs), C.short(pair), nil) == C.ERR {
+		return nil, CursesError{"setcchar", ERR}
+	}
+	return c, nil
+}
+
+// NewCcharExtended is like NewCchar, but takes a color pair number beyond
+// the range of a C short.
+func NewCcharExtended(s string, attrs int, pair int) (*Cchar, error) {
+	CheckThread()
+	c := new(Cchar)
+	ws := wcstr(s)
+	p := C.int(pair)
+	if C.setcchar((*C.cchar_t)(c), &ws[0], C.attr_t(attrs), 0, unsafe.Pointer(&p)) == C.ERR {
+		return nil, CursesError{"setcchar", ERR}
+	}
+	return c, nil
+}
+
+func (win *Window) AddCchar(c *Cchar) error {
+	CheckThread()
+	if C.wadd_wch((*C.WINDOW)(win), (*C.cchar_t)(c)) == C.ERR {
+		return CursesError{"wadd_wch", ERR}
+	}
+	return nil
+}
+
+func (win *Window) MvaddCchar(y, x int, c *Cchar) error {
+	CheckThread()
+	if C.mvwadd_wch((*C.WINDOW)(win), C.int(y), C.int(x), (*C.cchar_t)(c)) == C.ERR {
+		return CursesError{"mvwadd_wch", ERR}
+	}
+	return nil
+}
+
+// AddRune writes a single character at the cursor using the given
+// attributes and color pair, advancing the cursor by the width of the cell.
+func (win *Window) AddRune(r rune, attrs int, pair int16) error {
+	c, err := NewCchar(string(r), attrs, pair)
+	if err != nil {
+		return err
+	}
+	return win.AddCchar(c)
+}
+
+func (win *Window) MvaddRune(y, x int, r rune, attrs int, pair int16) error {
+	c, err := NewCchar(string(r), attrs, pair)
+	if err != nil {
+		return err
+	}
+	return win.MvaddCchar(y, x, c)
+}
+
+func (win *Window) AddWstr(str string) error {
+	CheckThread()
+	ws := wcstr(str)
+	if C.waddwstr((*C.WINDOW)(win), &ws[0]) == C.ERR {
+		return CursesError{"waddwstr", ERR}
+	}
+	return nil
+}
+
+func (win *Window) MvaddWstr(y, x int, str string) error {
+	CheckThread()
+	ws := wcstr(str)
+	if C.mvwaddwstr((*C.WINDOW)(win), C.int(y), C.int(x), &ws[0]) == C.ERR {
+		return CursesError{"mvwaddwstr", ERR}
+	}
+	return nil
+}
+
+func (win *Window) InsWstr(str string) error {
+	CheckThread()
+	ws := wcstr(str)
+	if C.wins_wstr((*C.WINDOW)(win), &ws[0]) == C.ERR {
+		return CursesError{"wins_wstr", ERR}
+	}
+	return nil
+}
+
+// GetWch reads a character from the terminal. If isKey is set, ch holds one
+// of the KEY_* codes rather than a character.
+func (win *Window) GetWch() (ch rune, isKey bool, err error) {
+	CheckThread()
+	var wc C.wint_t
+	switch C.wget_wch((*C.WINDOW)(win), &wc) {
+	case C.ERR:
+		return 0, false, CursesError{"wget_wch", ERR}
+	case C.KEY_CODE_YES:
+		return rune(wc), true, nil
+	}
+	return rune(wc), false, nil
+}
+
+func (win *Window) MvgetWch(y, x int) (ch rune, isKey bool, err error) {
+	CheckThread()
+	var wc C.wint_t
+	switch C.mvwget_wch((*C.WINDOW)(win), C.int(y), C.int(x), &wc) {
+	case C.ERR:
+		return 0, false, CursesError{"mvwget_wch", ERR}
+	case C.KEY_CODE_YES:
+		return rune(wc), true, nil
+	}
+	return rune(wc), false, nil
+}
+
+// GetnWstr reads at most length characters up to a newline, with the
+// usual line editing. A negative length is an E_BAD_ARGUMENT error.
+func (win *Window) GetnWstr(length int) (string, error) {
+	CheckThread()
+	if length < 0 {
+		return "", CursesError{"wgetn_wstr", E_BAD_ARGUMENT}
+	}
+	buf := make([]C.wint_t, length+1)
+	if C.wgetn_wstr((*C.WINDOW)(win), &buf[0], C.int(length)) == C.ERR {
+		return "", CursesError{"wgetn_wstr", ERR}
+	}
+	rs := make([]rune, 0, length)
+	for _, wc := range buf {
+		if wc == 0 {
+			break
+		}
+		rs = append(rs, rune(wc))
+	}
+	return string(rs), nil
+}
+
+// wcstr converts s to a NUL-terminated wide string.
+func wcstr(s string) []C.wchar_t {
+	ws := make([]C.wchar_t, 0, len(s)+1)
+	for _, r := range s {
+		ws = append(ws, C.wchar_t(r))
+	}
+	return append(ws, 0)
+}
@@ -0,0 +1,28 @@
+package curses_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+)
+
+func TestGetnWstr(t *testing.T) {
+	term, err := cursestest.New(3, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+	w := term.Stdscr()
+
+	if _, err := w.GetnWstr(-1); !errors.Is(err, curses.ErrBadArgument) {
+		t.Errorf("GetnWstr(-1) error = %v, want ErrBadArgument", err)
+	}
+
+	term.Send("héllo\n")
+	s, err := w.GetnWstr(3)
+	if err != nil || s != "hél" {
+		t.Errorf("GetnWstr(3) = %q, %v; want %q", s, err, "hél")
+	}
+}
@@ -10,10 +10,14 @@ package curses
 // #include <ncursesw/curses.h>
 // #include <stdlib.h>
 // #include <locale.h>
+//
+// /* curses.h may define wgetdelay as a macro that reads the WINDOW struct,
+//  * whose layout the _Bool define above changes, so call the function. */
+// static int _wgetdelay(WINDOW *win) { return (wgetdelay)(win); }
 import "C"
 import (
+	"bytes"
 	"fmt"
-	"os"
 	"unsafe"
 )
 
@@ -23,322 +27,486 @@ type Window C.WINDOW
 var Stdwin *Window = nil
 
 func Initscr() (*Window, error) {
+	CheckThread()
 	s := C.CString("")
 	defer C.free(unsafe.Pointer(s))
 	C.setlocale(C.LC_ALL, s)
 
-	Stdwin = (*Window)(C.initscr())
-
-	if Stdwin == nil {
-		return nil, CursesError{"initscr failed"}
+	if C.initscr() == nil {
+		return nil, CursesError{"initscr", ERR}
 	}
+	syncGlobals()
 
 	return Stdwin, nil
 }
 
 func Newwin(rows int, cols int, starty int, startx int) (*Window, error) {
+	CheckThread()
 	nw := (*Window)(C.newwin(C.int(rows), C.int(cols), C.int(starty), C.int(startx)))
 
 	if nw == nil {
-		return nil, CursesError{"newwin failed"}
+		return nil, CursesError{"newwin", ERR}
 	}
 
 	return nw, nil
 }
 
 func Newpad(y, x int) (*Window, error) {
-	npw, err := C.newpad(C.int(y), C.int(x))
+	CheckThread()
+	npw, errno := C.newpad(C.int(y), C.int(x))
 	np := (*Window)(npw)
-	if err != nil && np == nil {
-		return nil, CursesError{fmt.Sprintf("newpad failed: %v", err)}
+	if np == nil {
+		return nil, nullError("newpad", errno)
 	}
 	return np, nil
 }
 
 func (win *Window) Del() error {
+	CheckThread()
 	if C.delwin((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"delete failed"}
+		return CursesError{"delwin", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Subwin(rows, cols, starty, startx int) (*Window, error) {
+	CheckThread()
 	sw := (*Window)(C.subwin((*C.WINDOW)(win), C.int(rows), C.int(cols), C.int(starty), C.int(startx)))
 	if sw == nil {
-		return nil, CursesError{"subwin failed"}
+		return nil, CursesError{"subwin", ERR}
 	}
 	return sw, nil
 }
 
 func (win *Window) Subpad(rows, cols, starty, startx int) (*Window, error) {
-	spw, err := C.subpad((*C.WINDOW)(win), C.int(rows), C.int(cols), C.int(starty), C.int(startx))
+	CheckThread()
+	spw, errno := C.subpad((*C.WINDOW)(win), C.int(rows), C.int(cols), C.int(starty), C.int(startx))
 	sp := (*Window)(spw)
-	if err != nil && sp == nil {
-		return nil, CursesError{fmt.Sprintf("subpad failed: %v", err)}
+	if sp == nil {
+		return nil, nullError("subpad", errno)
 	}
 	return sp, nil
 }
 
+// nullError is the error for op returning NULL. It wraps errno as well, if
+// the call set it.
+func nullError(op string, errno error) error {
+	if errno != nil {
+		return fmt.Errorf("%w: %w", CursesError{op, ERR}, errno)
+	}
+	return CursesError{op, ERR}
+}
+
 func (win *Window) Derwin(rows int, cols int, starty int, startx int) (*Window, error) {
+	CheckThread()
 	dw := (*Window)(C.derwin((*C.WINDOW)(win), C.int(rows), C.int(cols), C.int(starty), C.int(startx)))
 	if dw == nil {
-		return nil, CursesError{"derwin failed"}
+		return nil, CursesError{"derwin", ERR}
 	}
 	return dw, nil
 }
 
 func (win *Window) Dupwin() (*Window, error) {
+	CheckThread()
 	dw := (*Window)(C.dupwin((*C.WINDOW)(win)))
 	if dw == nil {
-		return nil, CursesError{"dupwin failed"}
+		return nil, CursesError{"dupwin", ERR}
 	}
 	return dw, nil
 }
 
 func (win *Window) Getch() int {
+	CheckThread()
 	return int(C.wgetch((*C.WINDOW)(win)))
 }
 
+// Getnstr is kept for compatibility and reads through GetnWstr.
 func (win *Window) Getnstr(length int) (string, error) {
-	buf := make([]byte, length)
-	r := C.wgetnstr((*C.WINDOW)(win), (*C.char)(unsafe.Pointer(&buf[0])), C.int(length))
-	if r == C.ERR {
-		return string(buf), CursesError{"wgetnstr failed"}
-	}
-	return string(buf), nil
+	return win.GetnWstr(length)
 }
 
 // func (win *Window) Getstr() (string, error) {
 // }
 
 func (win *Window) Move(x, y int) error {
+	CheckThread()
 	if C.wmove((*C.WINDOW)(win), C.int(y), C.int(x)) == C.ERR {
-		return CursesError{"wmove failed"}
+		return CursesError{"wmove", ERR}
+	}
+	return nil
+}
+
+func (win *Window) Mvwin(y, x int) error {
+	CheckThread()
+	if C.mvwin((*C.WINDOW)(win), C.int(y), C.int(x)) == C.ERR {
+		return CursesError{"mvwin", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Resize(rows, cols int) error {
+	CheckThread()
 	if C.wresize((*C.WINDOW)(win), C.int(rows), C.int(cols)) == C.ERR {
-		return CursesError{"wresize failed"}
+		return CursesError{"wresize", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Refresh() error {
+	CheckThread()
 	if C.wrefresh((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"refresh failed"}
+		return CursesError{"wrefresh", ERR}
+	}
+	return nil
+}
+
+// Noutrefresh copies the window to the virtual screen without updating the
+// terminal; call DoUpdate once all windows have been copied.
+func (win *Window) Noutrefresh() error {
+	CheckThread()
+	if C.wnoutrefresh((*C.WINDOW)(win)) == C.ERR {
+		return CursesError{"wnoutrefresh", ERR}
+	}
+	return nil
+}
+
+// Prefresh copies the pad area starting at pminrow, pmincol to the screen
+// rectangle sminrow, smincol, smaxrow, smaxcol and updates the terminal.
+func (win *Window) Prefresh(pminrow, pmincol, sminrow, smincol, smaxrow, smaxcol int) error {
+	CheckThread()
+	if C.prefresh((*C.WINDOW)(win), C.int(pminrow), C.int(pmincol), C.int(sminrow), C.int(smincol), C.int(smaxrow), C.int(smaxcol)) == C.ERR {
+		return CursesError{"prefresh", ERR}
+	}
+	return nil
+}
+
+func (win *Window) Pnoutrefresh(pminrow, pmincol, sminrow, smincol, smaxrow, smaxcol int) error {
+	CheckThread()
+	if C.pnoutrefresh((*C.WINDOW)(win), C.int(pminrow), C.int(pmincol), C.int(sminrow), C.int(smincol), C.int(smaxrow), C.int(smaxcol)) == C.ERR {
+		return CursesError{"pnoutrefresh", ERR}
+	}
+	return nil
+}
+
+func (win *Window) Pechochar(ch chtype) error {
+	CheckThread()
+	if C.pechochar((*C.WINDOW)(win), C.chtype(ch)) == C.ERR {
+		return CursesError{"pechochar", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Redrawln(beg_line, num_lines int) error {
+	CheckThread()
 	if C.wredrawln((*C.WINDOW)(win), C.int(beg_line), C.int(num_lines)) == C.ERR {
-		return CursesError{"wredrawln failed"}
+		return CursesError{"wredrawln", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Redrawin() error {
+	CheckThread()
 	if C.redrawwin((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"redrawin failed"}
+		return CursesError{"redrawwin", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Scroll() error {
+	CheckThread()
 	if C.scroll((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"scroll failed"}
+		return CursesError{"scroll", ERR}
+	}
+	return nil
+}
+
+// Scrl scrolls the window up by n lines, or down if n is negative. Scrolling
+// must be enabled with Scrollok.
+func (win *Window) Scrl(n int) error {
+	CheckThread()
+	if C.wscrl((*C.WINDOW)(win), C.int(n)) == C.ERR {
+		return CursesError{"wscrl", ERR}
+	}
+	return nil
+}
+
+// Insdelln inserts n blank lines above the cursor line, or deletes -n lines
+// starting at it if n is negative. The lines below are shifted accordingly.
+func (win *Window) Insdelln(n int) error {
+	CheckThread()
+	if C.winsdelln((*C.WINDOW)(win), C.int(n)) == C.ERR {
+		return CursesError{"winsdelln", ERR}
+	}
+	return nil
+}
+
+func (win *Window) Insertln() error {
+	CheckThread()
+	if C.winsertln((*C.WINDOW)(win)) == C.ERR {
+		return CursesError{"winsertln", ERR}
+	}
+	return nil
+}
+
+func (win *Window) Deleteln() error {
+	CheckThread()
+	if C.wdeleteln((*C.WINDOW)(win)) == C.ERR {
+		return CursesError{"wdeleteln", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Scrollok(b bool) error {
+	CheckThread()
 	if C.scrollok((*C.WINDOW)(win), bool2cint(b)) == C.ERR {
-		return CursesError{"scrollok failed"}
+		return CursesError{"scrollok", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Syncok(b bool) error {
+	CheckThread()
 	if C.syncok((*C.WINDOW)(win), bool2cint(b)) == C.ERR {
-		return CursesError{"syncok failed"}
+		return CursesError{"syncok", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Touchline(y, x int) error {
+	CheckThread()
 	if C.touchline((*C.WINDOW)(win), C.int(y), C.int(x)) == C.ERR {
-		return CursesError{"touchline failed"}
+		return CursesError{"touchline", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Touchwin() error {
+	CheckThread()
 	if C.touchwin((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"touchwin failed"}
+		return CursesError{"touchwin", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Untouchwin() error {
+	CheckThread()
 	if C.untouchwin((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"untouchwin failed"}
+		return CursesError{"untouchwin", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Clear() error {
+	CheckThread()
 	if C.wclear((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"wclear failed"}
+		return CursesError{"wclear", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Clearok(b bool) error {
+	CheckThread()
 	if C.clearok((*C.WINDOW)(win), bool2cint(b)) == C.ERR {
-		return CursesError{"clearok failed"}
+		return CursesError{"clearok", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Erase() error {
+	CheckThread()
 	if C.werase((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"werase failed"}
+		return CursesError{"werase", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Clrtobot() error {
+	CheckThread()
 	if C.wclrtobot((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"wclrtobot failed"}
+		return CursesError{"wclrtobot", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Clrtoeol() error {
+	CheckThread()
 	if C.wclrtoeol((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"wclrtoeol failed"}
+		return CursesError{"wclrtoeol", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Box(verch, horch chtype) error {
+	CheckThread()
 	if C.box((*C.WINDOW)(win), C.chtype(verch), C.chtype(horch)) == C.ERR {
-		return CursesError{"box failed"}
+		return CursesError{"box", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Border(ls, rs, ts, bs, tl, tr, bl, br chtype) error {
+	CheckThread()
 	if C.wborder((*C.WINDOW)(win), C.chtype(ls), C.chtype(rs), C.chtype(ts), C.chtype(bs), C.chtype(tl), C.chtype(tr), C.chtype(bl), C.chtype(br)) == C.ERR {
-		return CursesError{"wborder failed"}
+		return CursesError{"wborder", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Bkgd(colour chtype) error {
+	CheckThread()
 	if C.wbkgd((*C.WINDOW)(win), C.chtype(colour)) == C.ERR {
-		return CursesError{"bkgd failed"}
+		return CursesError{"wbkgd", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Bkgdset(colour chtype) {
+	CheckThread()
 	C.wbkgdset((*C.WINDOW)(win), C.chtype(colour))
 }
 
 func (win *Window) Getbkgd() int {
+	CheckThread()
 	return int(C.getbkgd((*C.WINDOW)(win)))
 }
 
-func Getwin(file *os.File) *Window { // WINDOW * getwin (FILE *);
-	return nil
-}
-
 func (win *Window) Idcok(b bool) {
+	CheckThread()
 	C.idcok((*C.WINDOW)(win), bool2cint(b))
 }
 
 func (win *Window) Idlok(b bool) error {
+	CheckThread()
 	a := bool2cint(b)
 	r := C.idlok((*C.WINDOW)(win), a)
 	if r == C.ERR {
-		return CursesError{"idlok failed"}
+		return CursesError{"idlok", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Nodelay(b bool) error {
+	CheckThread()
 	a := bool2cint(b)
 	r := C.nodelay((*C.WINDOW)(win), a)
 	if r == C.ERR {
-		return CursesError{"nodelay failed"}
+		return CursesError{"nodelay", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Notimeout(b bool) error {
+	CheckThread()
 	a := bool2cint(b)
 	r := C.notimeout((*C.WINDOW)(win), a)
 	if r == C.ERR {
-		return CursesError{"notimeout failed"}
+		return CursesError{"notimeout", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Timeout(delay int) {
+	CheckThread()
 	C.wtimeout((*C.WINDOW)(win), C.int(delay))
 }
 
+// Getdelay returns the input delay of win as set by Timeout: negative for
+// blocking reads, zero for none, otherwise a wait in milliseconds.
+func (win *Window) Getdelay() int {
+	CheckThread()
+	return int(C._wgetdelay((*C.WINDOW)(win)))
+}
+
 func (win *Window) Keypad(b bool) error {
+	CheckThread()
 	a := bool2cint(b)
 	if C.keypad((*C.WINDOW)(win), a) == C.ERR {
-		return CursesError{"kaypad failed"}
+		return CursesError{"keypad", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Meta(b bool) error {
+	CheckThread()
 	a := bool2cint(b)
 	if C.meta((*C.WINDOW)(win), a) == C.ERR {
-		return CursesError{"meta failed"}
+		return CursesError{"meta", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Intrflush(b bool) error {
+	CheckThread()
 	a := bool2cint(b)
 	if C.intrflush((*C.WINDOW)(win), a) == C.ERR {
-		return CursesError{"intrflush failed"}
+		return CursesError{"intrflush", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Overlay(ow *Window) error {
+	CheckThread()
 	if C.overlay((*C.WINDOW)(win), (*C.WINDOW)(ow)) == C.ERR {
-		return CursesError{"overlay failed"}
+		return CursesError{"overlay", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Overwrite(ow *Window) error {
+	CheckThread()
 	if C.overwrite((*C.WINDOW)(win), (*C.WINDOW)(ow)) == C.ERR {
-		return CursesError{"overwrite failed"}
+		return CursesError{"overwrite", ERR}
+	}
+	return nil
+}
+
+// Copywin copies the part of win starting at sminrow, smincol onto the
+// rectangle dminrow, dmincol, dmaxrow, dmaxcol of dst. If overlay is set,
+// blank characters are not copied.
+func (win *Window) Copywin(dst *Window, sminrow, smincol, dminrow, dmincol, dmaxrow, dmaxcol int, overlay bool) error {
+	CheckThread()
+	if C.copywin((*C.WINDOW)(win), (*C.WINDOW)(dst), C.int(sminrow), C.int(smincol), C.int(dminrow), C.int(dmincol), C.int(dmaxrow), C.int(dmaxcol), bool2cint(overlay)) == C.ERR {
+		return CursesError{"copywin", ERR}
 	}
 	return nil
 }
 
-func (win *Window) Copywin(sminrow, smincol, dminrow, dmincol, dmaxrow, dmaxcol int, over bool) (*Window, error) {
-	dst := new(Window)
-	a := bool2cint(over)
-	if C.copywin((*C.WINDOW)(win), (*C.WINDOW)(dst), C.int(sminrow), C.int(smincol), C.int(dminrow), C.int(dmincol), C.int(dmaxrow), C.int(dmaxcol), a) == C.ERR {
-		return nil, CursesError{"copywin failed"}
+// Blit copies the src rectangle of win to dst with its top left corner at
+// at, clipping it to both windows. If transparent is set, blank characters
+// are not copied. Nothing is done if the clipped rectangle is empty.
+func (win *Window) Blit(dst *Window, src Rect, at Point, transparent bool) error {
+	if src.Y < 0 {
+		src.Rows += src.Y
+		at.Y -= src.Y
+		src.Y = 0
+	}
+	if src.X < 0 {
+		src.Cols += src.X
+		at.X -= src.X
+		src.X = 0
+	}
+	if at.Y < 0 {
+		src.Rows += at.Y
+		src.Y -= at.Y
+		at.Y = 0
 	}
-	return dst, nil
+	if at.X < 0 {
+		src.Cols += at.X
+		src.X -= at.X
+		at.X = 0
+	}
+	srows, scols := win.Getmaxyx()
+	drows, dcols := dst.Getmaxyx()
+	rows := min(src.Rows, srows-src.Y, drows-at.Y)
+	cols := min(src.Cols, scols-src.X, dcols-at.X)
+	if rows <= 0 || cols <= 0 {
+		return nil
+	}
+	return win.Copywin(dst, src.Y, src.X, at.Y, at.X, at.Y+rows-1, at.X+cols-1, transparent)
 }
 
 func (win *Window) Immedok(b bool) {
+	CheckThread()
 	C.immedok((*C.WINDOW)(win), bool2cint(b))
 }
 
@@ -353,136 +521,241 @@ func bool2cint(b bool) C.int {
 }
 
 func (win *Window) Getparent() (*Window, error) {
+	CheckThread()
 	r := (*C.WINDOW)(C.wgetparent((*C.WINDOW)(win)))
 	if r == nil {
-		return nil, CursesError{"wgetparent failed"}
+		return nil, CursesError{"wgetparent", ERR}
 	}
 	return (*Window)(r), nil
 }
 
 func (win *Window) Leaveok(b bool) error {
+	CheckThread()
 	if C.leaveok((*C.WINDOW)(win), bool2cint(b)) == C.ERR {
-		return CursesError{"leaveok failed"}
+		return CursesError{"leaveok", ERR}
 	}
 	return nil
 }
 
 func (win *Window) AttrOn(attr int) error {
+	CheckThread()
 	if C.wattr_on((*C.WINDOW)(win), C.attr_t(attr), nil) == C.ERR {
-		return CursesError{"wattr_on failed"}
+		return CursesError{"wattr_on", ERR}
 	}
 	return nil
 }
 
 func (win *Window) AttrOff(attr int) error {
+	CheckThread()
 	if C.wattr_off((*C.WINDOW)(win), C.attr_t(attr), nil) == C.ERR {
-		return CursesError{"wattr_off failed"}
+		return CursesError{"wattr_off", ERR}
 	}
 	return nil
 }
 
 func (win *Window) AttrSet(attr int, color int16) error {
+	CheckThread()
 	if C.wattr_set((*C.WINDOW)(win), C.attr_t(attr), C.short(color), nil) == C.ERR {
-		return CursesError{"wattr_set failed"}
+		return CursesError{"wattr_set", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Attrset(attr int) error {
+	CheckThread()
 	if C.wattrset((*C.WINDOW)(win), C.int(attr)) == C.ERR {
-		return CursesError{"wattrset failed"}
+		return CursesError{"wattrset", ERR}
 	}
 	return nil
 }
 
 func (win *Window) AttrGet() (int, int16, error) {
+	CheckThread()
 	var attrs C.attr_t
 	var pair C.short
 	if C.wattr_get((*C.WINDOW)(win), &attrs, &pair, nil) == C.ERR {
-		return 0, 0, CursesError{"wattr_get failed"}
+		return 0, 0, CursesError{"wattr_get", ERR}
 	}
 	return int(attrs), int16(pair), nil
 }
 
 func (win *Window) Chgat(n, attrs int, color int16) error {
+	CheckThread()
 	if C.wchgat((*C.WINDOW)(win), C.int(n), C.attr_t(attrs), C.short(color), nil) == C.ERR {
-		return CursesError{"wchgat failed"}
+		return CursesError{"wchgat", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Mvchgat(y, x, n, attrs int, color int16) error {
+	CheckThread()
 	if C.mvwchgat((*C.WINDOW)(win), C.int(y), C.int(x), C.int(n), C.attr_t(attrs), C.short(color), nil) == C.ERR {
-		return CursesError{"mvwchgat failed"}
+		return CursesError{"mvwchgat", ERR}
+	}
+	return nil
+}
+
+// AttrSetExtended is like AttrSet, but takes a color pair number beyond the
+// range of a C short, as created with Init_extended_pair.
+func (win *Window) AttrSetExtended(attr int, pair int) error {
+	CheckThread()
+	p := C.int(pair)
+	if C.wattr_set((*C.WINDOW)(win), C.attr_t(attr), 0, unsafe.Pointer(&p)) == C.ERR {
+		return CursesError{"wattr_set", ERR}
+	}
+	return nil
+}
+
+func (win *Window) AttrGetExtended() (int, int, error) {
+	CheckThread()
+	var attrs C.attr_t
+	var pair C.short
+	var ext C.int
+	if C.wattr_get((*C.WINDOW)(win), &attrs, &pair, unsafe.Pointer(&ext)) == C.ERR {
+		return 0, 0, CursesError{"wattr_get", ERR}
+	}
+	return int(attrs), int(ext), nil
+}
+
+func (win *Window) ChgatExtended(n, attrs int, pair int) error {
+	CheckThread()
+	p := C.int(pair)
+	if C.wchgat((*C.WINDOW)(win), C.int(n), C.attr_t(attrs), 0, unsafe.Pointer(&p)) == C.ERR {
+		return CursesError{"wchgat", ERR}
+	}
+	return nil
+}
+
+func (win *Window) MvchgatExtended(y, x, n, attrs int, pair int) error {
+	CheckThread()
+	p := C.int(pair)
+	if C.mvwchgat((*C.WINDOW)(win), C.int(y), C.int(x), C.int(n), C.attr_t(attrs), 0, unsafe.Pointer(&p)) == C.ERR {
+		return CursesError{"mvwchgat", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Getyx() (int, int) {
+	CheckThread()
 	return int(C.getcury((*C.WINDOW)(win))), int(C.getcurx((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getparyx() (int, int) {
+	CheckThread()
 	return int(C.getpary((*C.WINDOW)(win))), int(C.getparx((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getbegyx() (int, int) {
+	CheckThread()
 	return int(C.getbegy((*C.WINDOW)(win))), int(C.getbegx((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getmaxyx() (int, int) {
+	CheckThread()
 	return int(C.getmaxy((*C.WINDOW)(win))), int(C.getmaxx((*C.WINDOW)(win)))
 }
 
 func (win *Window) Inch() chtype {
+	CheckThread()
 	return chtype(C.winch((*C.WINDOW)(win)))
 }
 
 func (win *Window) Mvinch(y, x int) chtype {
+	CheckThread()
 	return chtype(C.mvwinch((*C.WINDOW)(win), C.int(y), C.int(x)))
 }
 
 func (win *Window) Addch(c byte) {
+	CheckThread()
 	C.waddch((*C.WINDOW)(win), C.chtype(c))
 }
 
 func (win *Window) Mvaddch(x, y int, c chtype) chtype {
+	CheckThread()
 	return chtype(C.mvwaddch((*C.WINDOW)(win), C.int(y), C.int(x), C.chtype(c)))
 }
 
 func (win *Window) Insch(c chtype) error {
+	CheckThread()
 	if C.winsch((*C.WINDOW)(win), C.chtype(c)) == C.ERR {
-		return CursesError{"winsch failed"}
+		return CursesError{"winsch", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Mvinsch(y, x int, c chtype) error {
+	CheckThread()
 	if C.mvwinsch((*C.WINDOW)(win), C.int(y), C.int(x), C.chtype(c)) == C.ERR {
-		return CursesError{"mvwinsch failed"}
+		return CursesError{"mvwinsch", ERR}
+	}
+	return nil
+}
+
+// Insstr inserts str before the cursor, shifting the rest of the line right
+// and dropping what falls off its end. The cursor does not move.
+func (win *Window) Insstr(str string) error {
+	CheckThread()
+	s := C.CString(str)
+	defer C.free(unsafe.Pointer(s))
+	if C.winsstr((*C.WINDOW)(win), s) == C.ERR {
+		return CursesError{"winsstr", ERR}
+	}
+	return nil
+}
+
+// Insnstr is like Insstr, but inserts at most n bytes of str.
+func (win *Window) Insnstr(str string, n int) error {
+	CheckThread()
+	s := C.CString(str)
+	defer C.free(unsafe.Pointer(s))
+	if C.winsnstr((*C.WINDOW)(win), s, C.int(n)) == C.ERR {
+		return CursesError{"winsnstr", ERR}
+	}
+	return nil
+}
+
+func (win *Window) Mvinsstr(y, x int, str string) error {
+	CheckThread()
+	s := C.CString(str)
+	defer C.free(unsafe.Pointer(s))
+	if C.mvwinsstr((*C.WINDOW)(win), C.int(y), C.int(x), s) == C.ERR {
+		return CursesError{"mvwinsstr", ERR}
+	}
+	return nil
+}
+
+func (win *Window) Mvinsnstr(y, x int, str string, n int) error {
+	CheckThread()
+	s := C.CString(str)
+	defer C.free(unsafe.Pointer(s))
+	if C.mvwinsnstr((*C.WINDOW)(win), C.int(y), C.int(x), s, C.int(n)) == C.ERR {
+		return CursesError{"mvwinsnstr", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Delch() error {
+	CheckThread()
 	if C.wdelch((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"wdelch failed"}
+		return CursesError{"wdelch", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Mvdelch(y, x int) error {
+	CheckThread()
 	if C.mvwdelch((*C.WINDOW)(win), C.int(y), C.int(x)) == C.ERR {
-		return CursesError{"mvwdelch failed"}
+		return CursesError{"mvwdelch", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Addstr(str string) error {
+	CheckThread()
 	s := C.CString(str)
 	defer C.free(unsafe.Pointer(s))
 	if C.waddstr((*C.WINDOW)(win), s) == C.ERR {
-		return CursesError{"waddstr failed"}
+		return CursesError{"waddstr", ERR}
 	}
 	return nil
 }
@@ -492,93 +765,156 @@ func (win *Window) Mvaddstr(y, x int, str string) error {
 	return win.Addstr(str)
 }
 
+// Write implements io.Writer by adding p at the cursor with Addstr, so a
+// window can be used with fmt.Fprintf, text/tabwriter or log.Logger. NUL
+// bytes, which would end the string for curses, are added one by one with
+// waddch and show as ^@.
+//
+// Write stops at the first failure, typically when it runs past the bottom
+// right corner of a window that does not scroll. n then counts the bytes
+// before the failing segment, though part of that segment may have been
+// drawn.
+func (win *Window) Write(p []byte) (n int, err error) {
+	CheckThread()
+	for len(p) > 0 {
+		seg := p
+		if i := bytes.IndexByte(p, 0); i >= 0 {
+			seg = p[:i]
+		}
+		if len(seg) == 0 {
+			if C.waddch((*C.WINDOW)(win), 0) == C.ERR {
+				return n, CursesError{"waddch", ERR}
+			}
+			seg = p[:1]
+		} else if err := win.Addstr(string(seg)); err != nil {
+			return n, err
+		}
+		n += len(seg)
+		p = p[len(seg):]
+	}
+	return n, nil
+}
+
+// Printf formats according to format and adds the result at the cursor
+// with Write.
+func (win *Window) Printf(format string, v ...interface{}) error {
+	_, err := fmt.Fprintf(win, format, v...)
+	return err
+}
+
+// Mvprintf moves the cursor to y, x and then behaves like Printf.
+func (win *Window) Mvprintf(y, x int, format string, v ...interface{}) error {
+	if err := win.Move(x, y); err != nil {
+		return err
+	}
+	return win.Printf(format, v...)
+}
+
 func (win *Window) Hline(ch chtype, n int) error {
+	CheckThread()
 	if C.whline((*C.WINDOW)(win), C.chtype(ch), C.int(n)) == C.ERR {
-		return CursesError{"whline failed"}
+		return CursesError{"whline", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Vline(ch chtype, n int) error {
+	CheckThread()
 	if C.wvline((*C.WINDOW)(win), C.chtype(ch), C.int(n)) == C.ERR {
-		return CursesError{"wvline failed"}
+		return CursesError{"wvline", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Mvhline(y, x int, ch chtype, n int) error {
+	CheckThread()
 	if C.mvwhline((*C.WINDOW)(win), C.int(y), C.int(x), C.chtype(ch), C.int(n)) == C.ERR {
-		return CursesError{"mvwhline failed"}
+		return CursesError{"mvwhline", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Mvvline(y, x int, ch chtype, n int) error {
+	CheckThread()
 	if C.mvwvline((*C.WINDOW)(win), C.int(y), C.int(x), C.chtype(ch), C.int(n)) == C.ERR {
-		return CursesError{"mvwvline failed"}
+		return CursesError{"mvwvline", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Getattrs() int {
+	CheckThread()
 	return int(C.getattrs((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getcurx() int {
+	CheckThread()
 	return int(C.getcurx((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getcury() int {
+	CheckThread()
 	return int(C.getcury((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getbegx() int {
+	CheckThread()
 	return int(C.getbegx((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getbegy() int {
+	CheckThread()
 	return int(C.getbegy((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getmaxx() int {
+	CheckThread()
 	return int(C.getmaxx((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getmaxy() int {
+	CheckThread()
 	return int(C.getmaxy((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getparx() int {
+	CheckThread()
 	return int(C.getparx((*C.WINDOW)(win)))
 }
 
 func (win *Window) Getpary() int {
+	CheckThread()
 	return int(C.getpary((*C.WINDOW)(win)))
 }
 
 func (win *Window) Standout() error {
+	CheckThread()
 	if C.wstandout((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"wstandout failed"}
+		return CursesError{"wstandout", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Standend() error {
+	CheckThread()
 	if C.wstandend((*C.WINDOW)(win)) == C.ERR {
-		return CursesError{"wstandend failed"}
+		return CursesError{"wstandend", ERR}
 	}
 	return nil
 }
 
 func (win *Window) Syncdown() {
+	CheckThread()
 	C.wsyncdown((*C.WINDOW)(win))
 }
 
 func (win *Window) Syncup() {
+	CheckThread()
 	C.wsyncup((*C.WINDOW)(win))
 }
 
 func (win *Window) Enclose(y, x int) bool {
+	CheckThread()
 	if C.wenclose((*C.WINDOW)(win), C.int(y), C.int(x)) == C.FALSE {
 		return false
 	}
@@ -586,8 +922,9 @@ func (win *Window) Enclose(y, x int) bool {
 }
 
 func (win *Window) SetScrollRegion(top, bottom int) error {
+	CheckThread()
 	if C.wsetscrreg((*C.WINDOW)(win), C.int(top), C.int(bottom)) == C.ERR {
-		return CursesError{"wsetscrreg failed"}
+		return CursesError{"wsetscrreg", ERR}
 	}
 	return nil
 }
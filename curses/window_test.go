@@ -0,0 +1,96 @@
+package curses_test
+
+import (
+	"testing"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+)
+
+func TestBlit(t *testing.T) {
+	term, err := cursestest.New(4, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+	dst := term.Stdscr()
+	src, err := curses.Newwin(3, 4, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Del()
+	for y, s := range []string{"abcd", "efgh", "ijkl"} {
+		src.MvaddWstr(y, 0, s)
+	}
+
+	tests := []struct {
+		name string
+		src  curses.Rect
+		at   curses.Point
+		want string
+	}{
+		{"whole", curses.Rect{Y: 0, X: 0, Rows: 3, Cols: 4}, curses.Point{Y: 1, X: 1}, "\n abcd\n efgh\n ijkl"},
+		{"negative source", curses.Rect{Y: -1, X: -1, Rows: 3, Cols: 4}, curses.Point{Y: 0, X: 0}, "\n abc\n efg\n"},
+		{"negative target", curses.Rect{Y: 0, X: 0, Rows: 3, Cols: 4}, curses.Point{Y: -1, X: -2}, "gh\nkl\n\n"},
+		{"clipped to the target", curses.Rect{Y: 0, X: 0, Rows: 3, Cols: 4}, curses.Point{Y: 2, X: 4}, "\n\n    ab\n    ef"},
+		{"clipped to the source", curses.Rect{Y: 1, X: 2, Rows: 10, Cols: 10}, curses.Point{Y: 0, X: 0}, "gh\nkl\n\n"},
+		{"empty", curses.Rect{Y: 0, X: 0, Rows: 0, Cols: 4}, curses.Point{Y: 0, X: 0}, "\n\n\n"},
+		{"outside the target", curses.Rect{Y: 0, X: 0, Rows: 3, Cols: 4}, curses.Point{Y: 4, X: 0}, "\n\n\n"},
+		{"clipped away", curses.Rect{Y: -3, X: 0, Rows: 3, Cols: 4}, curses.Point{Y: 0, X: 0}, "\n\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst.Erase()
+			if err := src.Blit(dst, tt.src, tt.at, false); err != nil {
+				t.Fatal(err)
+			}
+			dst.Refresh()
+			g, err := term.Snapshot()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := g.String(); got != tt.want {
+				t.Errorf("screen = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	term, err := cursestest.New(3, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+
+	w, err := curses.Newwin(1, 8, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Del()
+	if n, err := w.Write([]byte("a\x00\x00b")); err != nil || n != 4 {
+		t.Errorf("Write = %d, %v; want 4, nil", n, err)
+	}
+	if s, _ := w.Mvinstr(0, 0, -1); s != "a^@^@b  " {
+		t.Errorf("window holds %q, want %q", s, "a^@^@b  ")
+	}
+
+	if err := w.Mvprintf(0, 0, "%s%d", "x\x00", 7); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := w.Mvinstr(0, 0, -1); s != "x^@7@b  " {
+		t.Errorf("window holds %q after Mvprintf, want %q", s, "x^@7@b  ")
+	}
+
+	// The window does not scroll, so writing past its last cell fails
+	// within the segment after the NUL.
+	w.Erase()
+	w.Move(0, 0)
+	n, err := w.Write([]byte("ab\x00cdefgh"))
+	if err == nil || n != 3 {
+		t.Errorf("Write past the end = %d, %v; want 3 and an error", n, err)
+	}
+	if s, _ := w.Mvinstr(0, 0, -1); s != "ab^@cdef" {
+		t.Errorf("window holds %q, want %q", s, "ab^@cdef")
+	}
+}
@@ -0,0 +1,47 @@
+package cursestest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("cursestest.update", false, "rewrite golden files instead of comparing against them")
+
+// Golden compares got with the file testdata/name.golden and fails t if
+// they differ. When the test binary runs with -cursestest.update the file
+// is written instead.
+func Golden(t testing.TB, name string, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -cursestest.update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("screen differs from %s\n--- got:\n%s\n--- want:\n%s", path, got, want)
+	}
+}
+
+// AssertGolden compares the text and styles of the grid with
+// testdata/name.golden, as Golden does.
+func (g *Grid) AssertGolden(t testing.TB, name string) {
+	t.Helper()
+	Golden(t, name, g.String()+"\n--\n"+g.Styles())
+}
+
+// AssertText compares only the text of the grid with testdata/name.golden.
+func (g *Grid) AssertText(t testing.TB, name string) {
+	t.Helper()
+	Golden(t, name, g.String()+"\n")
+}
@@ -0,0 +1,116 @@
+package cursestest
+
+import (
+	"strings"
+)
+
+// Grid is a snapshot of the rendered terminal screen.
+type Grid struct {
+	Rows, Cols       int
+	Cells            [][]Cell
+	CursorY, CursorX int
+	CursorVisible    bool
+}
+
+func (t *vt) grid() *Grid {
+	g := &Grid{
+		Rows:          t.rows,
+		Cols:          t.cols,
+		Cells:         make([][]Cell, t.rows),
+		CursorY:       t.y,
+		CursorX:       t.x,
+		CursorVisible: t.cursorOn,
+	}
+	for y, line := range t.cells {
+		g.Cells[y] = make([]Cell, len(line))
+		for x, c := range line {
+			c.Combining = append([]rune(nil), c.Combining...)
+			g.Cells[y][x] = c
+		}
+	}
+	return g
+}
+
+// Line returns the text of row y without trailing blanks.
+func (g *Grid) Line(y int) string {
+	var b strings.Builder
+	for _, c := range g.Cells[y] {
+		b.WriteString(c.String())
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// String returns the text of the screen, one line per row.
+func (g *Grid) String() string {
+	lines := make([]string, g.Rows)
+	for y := range lines {
+		lines[y] = g.Line(y)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Find returns the position of the first occurrence of s on a single row.
+func (g *Grid) Find(s string) (y, x int, ok bool) {
+	for y := 0; y < g.Rows; y++ {
+		// Walk the cells rather than the string so x counts columns.
+		for x := 0; x < g.Cols; x++ {
+			if g.Cells[y][x].Rune == 0 {
+				continue
+			}
+			var b strings.Builder
+			for _, c := range g.Cells[y][x:] {
+				b.WriteString(c.String())
+				if b.Len() >= len(s) {
+					break
+				}
+			}
+			if strings.HasPrefix(b.String(), s) {
+				return y, x, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// Styles describes the runs of cells that are not drawn in the default
+// style, one per line as "row:first-last attrs fg=N bg=N". Together with
+// String it makes a complete golden description of the screen.
+func (g *Grid) Styles() string {
+	var b strings.Builder
+	plain := Cell{Fg: ColorDefault, Bg: ColorDefault}
+	for y, line := range g.Cells {
+		for x := 0; x < len(line); {
+			end := x + 1
+			for end < len(line) && line[end].sameStyle(line[x]) {
+				end++
+			}
+			if c := line[x]; !c.sameStyle(plain) {
+				b.WriteString(itoa(y) + ":" + itoa(x) + "-" + itoa(end-1))
+				if c.Attr != 0 {
+					b.WriteString(" " + c.Attr.String())
+				}
+				if c.Fg != ColorDefault {
+					b.WriteString(" fg=" + colorName(c.Fg))
+				}
+				if c.Bg != ColorDefault {
+					b.WriteString(" bg=" + colorName(c.Bg))
+				}
+				b.WriteByte('\n')
+			}
+			x = end
+		}
+	}
+	return b.String()
+}
+
+func colorName(c int) string {
+	if c&ColorRGB != 0 {
+		const hex = "0123456789abcdef"
+		s := []byte("#000000")
+		for i := 0; i < 6; i++ {
+			s[6-i] = hex[(c>>(4*i))&0xf]
+		}
+		return string(s)
+	}
+	return itoa(c)
+}
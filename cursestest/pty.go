@@ -0,0 +1,85 @@
+package cursestest
+
+// #define _XOPEN_SOURCE 600
+// #include <stdlib.h>
+// #include <fcntl.h>
+// #include <sys/ioctl.h>
+// static int _set_winsize(int fd, int rows, int cols) {
+// 	struct winsize ws = {0};
+// 	ws.ws_row = rows;
+// 	ws.ws_col = cols;
+// 	return ioctl(fd, TIOCSWINSZ, &ws);
+// }
+import "C"
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// ptsname returns a static buffer.
+var ptsnameMu sync.Mutex
+
+// openPty opens a new pseudo-terminal pair. The master is non-blocking, so
+// a pending Read returns once it is closed.
+func openPty(rows, cols int) (master, slave *os.File, err error) {
+	fd, err := C.posix_openpt(C.O_RDWR | C.O_NOCTTY)
+	if fd < 0 {
+		return nil, nil, err
+	}
+	if r, err := C.grantpt(fd); r != 0 {
+		syscall.Close(int(fd))
+		return nil, nil, err
+	}
+	if r, err := C.unlockpt(fd); r != 0 {
+		syscall.Close(int(fd))
+		return nil, nil, err
+	}
+	ptsnameMu.Lock()
+	name := C.ptsname(fd)
+	var path string
+	if name != nil {
+		path = C.GoString(name)
+	}
+	ptsnameMu.Unlock()
+	if path == "" {
+		syscall.Close(int(fd))
+		return nil, nil, errors.New("cursestest: ptsname failed")
+	}
+	if r, err := C._set_winsize(fd, C.int(rows), C.int(cols)); r != 0 {
+		syscall.Close(int(fd))
+		return nil, nil, err
+	}
+	if err := syscall.SetNonblock(int(fd), true); err != nil {
+		syscall.Close(int(fd))
+		return nil, nil, err
+	}
+	master = os.NewFile(uintptr(fd), "/dev/ptmx")
+	slave, err = os.OpenFile(path, os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+	return master, slave, nil
+}
+
+// setWinsize changes the size of the terminal. It goes through SyscallConn
+// because Fd would put the master back into blocking mode.
+func setWinsize(master *os.File, rows, cols int) error {
+	rc, err := master.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var serr error
+	err = rc.Control(func(fd uintptr) {
+		if r, err := C._set_winsize(C.int(fd), C.int(rows), C.int(cols)); r != 0 {
+			serr = err
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return serr
+}
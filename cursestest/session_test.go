@@ -0,0 +1,120 @@
+package cursestest
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/orofarne/gocurse/curses"
+)
+
+// startSession starts a Session on a new pty and feeds what it writes to
+// a vt. finish closes the pty and returns the vt once all output is in.
+func startSession(t *testing.T, rows, cols int) (s *curses.Session, finish func() *vt) {
+	t.Helper()
+	for _, name := range []string{"LINES", "COLUMNS"} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+	master, slave, err := openPty(rows, cols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := newVT(rows, cols)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		io.Copy(v, master)
+	}()
+	s, err = curses.NewSession("xterm-256color", slave, slave)
+	if err != nil {
+		slave.Close()
+		master.Close()
+		t.Fatal(err)
+	}
+	return s, func() *vt {
+		slave.Close()
+		<-done
+		master.Close()
+		return v
+	}
+}
+
+func TestNewSession(t *testing.T) {
+	s, finish := startSession(t, 8, 30)
+	defer finish()
+	defer s.Close()
+
+	if s.Stdscr() == nil || s.Stdscr() != curses.Stdwin {
+		t.Fatalf("Stdscr() = %p, Stdwin = %p; want the same window", s.Stdscr(), curses.Stdwin)
+	}
+	if rows, cols := s.Size(); rows != 8 || cols != 30 {
+		t.Errorf("Size() = %d, %d; want 8, 30", rows, cols)
+	}
+	if s.Screen() == nil {
+		t.Error("Screen() = nil")
+	}
+	if err := s.Setup(curses.Options{Cbreak: true, Noecho: true, Keypad: true, Colors: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Stdscr().MvaddWstr(2, 3, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Stdscr().Refresh(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSessionRun(t *testing.T) {
+	s, finish := startSession(t, 8, 30)
+	defer finish()
+
+	failed := errors.New("failed")
+	err := s.Run(func(s *curses.Session) error { return failed })
+	if err != failed {
+		t.Errorf("Run = %v, want %v", err, failed)
+	}
+	if s.Screen() != nil {
+		t.Error("Run did not delete the screen")
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("second Close = %v, want nil", err)
+	}
+}
+
+func TestSessionRunPanic(t *testing.T) {
+	s, finish := startSession(t, 8, 30)
+	if err := s.Setup(curses.Options{Cbreak: true, Noecho: true, HideCursor: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		s.Run(func(s *curses.Session) error {
+			s.Stdscr().MvaddWstr(1, 1, "inside")
+			s.Stdscr().Refresh()
+			panic("boom")
+		})
+	}()
+	if recovered != "boom" {
+		t.Errorf("recovered %v, want the panic of f", recovered)
+	}
+	if s.Screen() != nil {
+		t.Error("Run did not delete the screen")
+	}
+
+	// endwin leaves the alternate screen and shows the cursor again.
+	v := finish()
+	if v.saved != nil {
+		t.Error("terminal still on the alternate screen")
+	}
+	g := v.grid()
+	if !g.CursorVisible {
+		t.Error("cursor still hidden")
+	}
+	if _, _, ok := g.Find("inside"); ok {
+		t.Errorf("screen drawn by f still shown:\n%s", g)
+	}
+}
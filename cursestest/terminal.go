@@ -0,0 +1,259 @@
+// Package cursestest runs curses on a pseudo-terminal and renders its
+// output into a grid of cells, so that programs using the curses, panels,
+// menus and forms packages can be tested without a real terminal.
+package cursestest
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+// #include <locale.h>
+// #include <stdlib.h>
+// #cgo LDFLAGS: -lncursesw
+//
+// /* utf8_ctype selects a UTF-8 character type, whatever the environment
+//  * says, so that wide characters render the same everywhere. */
+// static int utf8_ctype(void) {
+// 	static const char *names[] = {"C.UTF-8", "C.utf8", "en_US.UTF-8", "en_US.utf8"};
+// 	for (int i = 0; i < sizeof names / sizeof names[0]; i++)
+// 		if (setlocale(LC_CTYPE, names[i]) != NULL)
+// 			return 0;
+// 	return -1;
+// }
+import "C"
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/terminfo"
+)
+
+// DefaultTerm is the terminal type used by New.
+const DefaultTerm = "xterm-256color"
+
+// Terminal is a curses screen attached to a pseudo-terminal whose output is
+// interpreted by a terminal emulator.
+type Terminal struct {
+	Screen *curses.Screen
+
+	stdscr        *curses.Window
+	master, slave *os.File
+	rc            syscall.RawConn
+
+	mu     sync.Mutex
+	vt     *vt
+	rerr   error
+	done   chan struct{}
+	closed bool
+}
+
+// New starts a curses screen of rows by cols cells using DefaultTerm.
+func New(rows, cols int) (*Terminal, error) {
+	return NewTerm(DefaultTerm, rows, cols)
+}
+
+// NewTerm starts a curses screen of rows by cols cells for the terminal
+// type term, which must be an xterm or vt100 compatible entry. The screen
+// becomes the current one, as with Newterm. The character type of the
+// process locale is set to UTF-8, whatever the environment says, so that
+// tests render the same everywhere.
+func NewTerm(term string, rows, cols int) (*Terminal, error) {
+	master, slave, err := openPty(rows, cols)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := master.SyscallConn()
+	if err != nil {
+		master.Close()
+		slave.Close()
+		return nil, err
+	}
+
+	empty := C.CString("")
+	defer C.free(unsafe.Pointer(empty))
+	C.setlocale(C.LC_ALL, empty)
+	if C.utf8_ctype() != 0 {
+		master.Close()
+		slave.Close()
+		return nil, errors.New("cursestest: no UTF-8 locale available")
+	}
+	// Take the size from the pty, not from $LINES and $COLUMNS, nor from
+	// terminfo as use_env(FALSE) alone would.
+	C.use_env(C.TRUE)
+	C.use_tioctl(C.TRUE)
+
+	scr, err := curses.Newterm(term, slave, slave)
+	if err != nil {
+		master.Close()
+		slave.Close()
+		return nil, err
+	}
+	t := &Terminal{
+		Screen: scr,
+		stdscr: (*curses.Window)(unsafe.Pointer(C.stdscr)),
+		master: master,
+		slave:  slave,
+		rc:     rc,
+		vt:     newVT(rows, cols),
+		done:   make(chan struct{}),
+	}
+	go t.readLoop()
+	return t, nil
+}
+
+// Stdscr returns the standard screen window of the terminal.
+func (t *Terminal) Stdscr() *curses.Window {
+	return t.stdscr
+}
+
+// readLoop keeps draining the pty so that curses never blocks on a full
+// buffer while the test is not looking at the screen.
+func (t *Terminal) readLoop() {
+	defer close(t.done)
+	err := t.rc.Read(func(fd uintptr) bool {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		return t.drain(fd)
+	})
+	if err != nil {
+		t.mu.Lock()
+		if t.rerr == nil {
+			t.rerr = err
+		}
+		t.mu.Unlock()
+	}
+}
+
+// drain feeds everything that can be read without blocking to the emulator.
+// It reports whether reading is over, because of EOF or an error.
+func (t *Terminal) drain(fd uintptr) bool {
+	var buf [4096]byte
+	for {
+		n, err := syscall.Read(int(fd), buf[:])
+		if n > 0 {
+			t.vt.Write(buf[:n])
+		}
+		switch {
+		case err == syscall.EAGAIN:
+			return false
+		case err == syscall.EINTR:
+			continue
+		case err != nil:
+			if err != syscall.EIO && t.rerr == nil {
+				t.rerr = err
+			}
+			return true
+		case n == 0:
+			return true
+		}
+	}
+}
+
+// Send writes s to the terminal as if it had been typed.
+func (t *Terminal) Send(s string) error {
+	_, err := io.WriteString(t.master, s)
+	return err
+}
+
+// keyCaps maps key codes to the terminfo capabilities of their sequences.
+var keyCaps = map[int]string{
+	curses.KEY_UP:        "kcuu1",
+	curses.KEY_DOWN:      "kcud1",
+	curses.KEY_LEFT:      "kcub1",
+	curses.KEY_RIGHT:     "kcuf1",
+	curses.KEY_HOME:      "khome",
+	curses.KEY_END:       "kend",
+	curses.KEY_NPAGE:     "knp",
+	curses.KEY_PPAGE:     "kpp",
+	curses.KEY_IC:        "kich1",
+	curses.KEY_DC:        "kdch1",
+	curses.KEY_BACKSPACE: "kbs",
+	curses.KEY_ENTER:     "kent",
+	curses.KEY_BTAB:      "kcbt",
+	curses.KEY_F0 + 1:    "kf1",
+	curses.KEY_F0 + 2:    "kf2",
+	curses.KEY_F0 + 3:    "kf3",
+	curses.KEY_F0 + 4:    "kf4",
+	curses.KEY_F0 + 5:    "kf5",
+	curses.KEY_F0 + 6:    "kf6",
+	curses.KEY_F0 + 7:    "kf7",
+	curses.KEY_F0 + 8:    "kf8",
+	curses.KEY_F0 + 9:    "kf9",
+	curses.KEY_F0 + 10:   "kf10",
+	curses.KEY_F0 + 11:   "kf11",
+	curses.KEY_F0 + 12:   "kf12",
+}
+
+// SendKey sends the sequence the terminal produces for the KEY_* code key.
+// Keypad mode must be enabled on the window reading it.
+func (t *Terminal) SendKey(key int) error {
+	cap, ok := keyCaps[key]
+	if !ok {
+		return errors.New("cursestest: no sequence known for " + curses.KeyName(key))
+	}
+	t.Screen.SetTerm()
+	seq, err := terminfo.Tigetstr(cap)
+	if err != nil {
+		return err
+	}
+	return t.Send(seq)
+}
+
+// Snapshot returns the screen as rendered from all output written so far.
+func (t *Terminal) Snapshot() (*Grid, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.closed {
+		// Output is written synchronously by curses, so whatever the read
+		// loop has not consumed yet is waiting in the pty.
+		if err := t.rc.Control(func(fd uintptr) { t.drain(fd) }); err != nil {
+			return nil, err
+		}
+	}
+	if t.rerr != nil {
+		return nil, t.rerr
+	}
+	return t.vt.grid(), nil
+}
+
+// Resize changes the size of the terminal and lets curses follow it with
+// curses.HandleResize, as an application would on SIGWINCH.
+func (t *Terminal) Resize(rows, cols int) error {
+	if err := setWinsize(t.master, rows, cols); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	t.vt.resize(rows, cols)
+	t.mu.Unlock()
+	t.Screen.SetTerm()
+	return curses.HandleResize(curses.ResizeEvent{Rows: rows, Cols: cols})
+}
+
+// Close ends curses on the terminal, frees the screen and closes the pty.
+func (t *Terminal) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	t.mu.Unlock()
+
+	t.Screen.SetTerm()
+	err := curses.Endwin()
+	t.Screen.DelScreen()
+	if cerr := t.slave.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := t.master.Close(); err == nil {
+		err = cerr
+	}
+	<-t.done
+	return err
+}
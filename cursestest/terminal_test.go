@@ -0,0 +1,67 @@
+package cursestest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/orofarne/gocurse/curses"
+)
+
+func TestTerminal(t *testing.T) {
+	// The result must not depend on the locale of the environment.
+	for _, v := range []string{"LANG", "LC_ALL", "LC_CTYPE"} {
+		if old, ok := os.LookupEnv(v); ok {
+			os.Unsetenv(v)
+			defer os.Setenv(v, old)
+		}
+	}
+
+	term, err := New(6, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+	w := term.Stdscr()
+	curses.Start_color()
+	curses.Init_pair(1, curses.COLOR_RED, curses.COLOR_BLUE)
+	curses.Cbreak()
+	curses.Noecho()
+	w.Keypad(true)
+
+	w.Box(0, 0)
+	w.Mvprintf(1, 2, "héllo %d", 42)
+	w.MvaddRune(2, 2, '漢', curses.A_BOLD, 1)
+	w.Refresh()
+
+	g, err := term.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.AssertGolden(t, "terminal")
+
+	term.Send("ж")
+	if ch, isKey, err := w.GetWch(); err != nil || isKey || ch != 'ж' {
+		t.Errorf("GetWch = %q, %v, %v; want ж", ch, isKey, err)
+	}
+	if err := term.SendKey(curses.KEY_F0 + 1); err != nil {
+		t.Fatal(err)
+	}
+	if ch, isKey, err := w.GetWch(); err != nil || !isKey || ch != curses.KEY_F0+1 {
+		t.Errorf("GetWch = %d, %v, %v; want KEY_F(1)", ch, isKey, err)
+	}
+
+	if err := term.Resize(4, 10); err != nil {
+		t.Fatal(err)
+	}
+	if rows, cols := w.Getmaxyx(); rows != 4 || cols != 10 {
+		t.Errorf("stdscr is %dx%d after Resize, want 4x10", rows, cols)
+	}
+	w.Erase()
+	w.Box(0, 0)
+	w.Refresh()
+	g, err = term.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.AssertText(t, "terminal_resized")
+}
@@ -0,0 +1,725 @@
+package cursestest
+
+import (
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Attr is a set of character attributes as rendered by the terminal.
+type Attr uint16
+
+const (
+	AttrBold Attr = 1 << iota
+	AttrDim
+	AttrItalic
+	AttrUnderline
+	AttrBlink
+	AttrReverse
+	AttrInvisible
+	AttrStrike
+)
+
+var attrNames = []struct {
+	a    Attr
+	name string
+}{
+	{AttrBold, "bold"},
+	{AttrDim, "dim"},
+	{AttrItalic, "italic"},
+	{AttrUnderline, "underline"},
+	{AttrBlink, "blink"},
+	{AttrReverse, "reverse"},
+	{AttrInvisible, "invisible"},
+	{AttrStrike, "strike"},
+}
+
+func (a Attr) String() string {
+	s := ""
+	for _, n := range attrNames {
+		if a&n.a != 0 {
+			if s != "" {
+				s += "|"
+			}
+			s += n.name
+		}
+	}
+	return s
+}
+
+// ColorDefault is the terminal's default foreground or background color.
+// Other colors are palette indexes, or RGB values with ColorRGB set.
+const (
+	ColorDefault = -1
+	ColorRGB     = 1 << 24
+)
+
+// Cell is one character cell of the rendered screen. The second column of
+// a wide character holds a Cell with Rune 0.
+type Cell struct {
+	Rune      rune
+	Combining []rune
+	Attr      Attr
+	Fg, Bg    int
+}
+
+func (c Cell) String() string {
+	if c.Rune == 0 {
+		return ""
+	}
+	return string(append([]rune{c.Rune}, c.Combining...))
+}
+
+func (c Cell) sameStyle(o Cell) bool {
+	return c.Attr == o.Attr && c.Fg == o.Fg && c.Bg == o.Bg
+}
+
+// vt interprets the output of ncurses for the xterm and vt100 families of
+// terminals and keeps the resulting screen.
+type vt struct {
+	rows, cols int
+	cells      [][]Cell
+	saved      [][]Cell // the normal screen while the alternate one is shown
+
+	y, x      int
+	wrapNext  bool
+	pen       Cell
+	top, bot  int
+	autowrap  bool
+	insert    bool
+	cursorOn  bool
+	g         [2]bool // whether G0 and G1 hold the line drawing set
+	shift     int
+	lastRune  rune
+	savedCurs struct {
+		y, x int
+		pen  Cell
+	}
+
+	state  int
+	params []byte
+	inter  []byte
+	utf    []byte
+}
+
+const (
+	stGround = iota
+	stEscape
+	stCharset
+	stCSI
+	stString
+	stStringEsc
+)
+
+func newVT(rows, cols int) *vt {
+	t := &vt{rows: rows, cols: cols}
+	t.reset()
+	return t
+}
+
+func (t *vt) reset() {
+	t.pen = Cell{Rune: ' ', Fg: ColorDefault, Bg: ColorDefault}
+	t.cells = t.blankScreen()
+	t.saved = nil
+	t.y, t.x, t.wrapNext = 0, 0, false
+	t.top, t.bot = 0, t.rows-1
+	t.autowrap, t.insert, t.cursorOn = true, false, true
+	t.g, t.shift = [2]bool{}, 0
+}
+
+func (t *vt) blank() Cell {
+	return Cell{Rune: ' ', Fg: t.pen.Fg, Bg: t.pen.Bg}
+}
+
+func (t *vt) blankLine() []Cell {
+	l := make([]Cell, t.cols)
+	b := t.blank()
+	for i := range l {
+		l[i] = b
+	}
+	return l
+}
+
+func (t *vt) blankScreen() [][]Cell {
+	s := make([][]Cell, t.rows)
+	for i := range s {
+		s[i] = t.blankLine()
+	}
+	return s
+}
+
+// resize changes the size of the screen, keeping the top left content.
+func (t *vt) resize(rows, cols int) {
+	resize := func(old [][]Cell) [][]Cell {
+		if old == nil {
+			return nil
+		}
+		s := make([][]Cell, rows)
+		for y := range s {
+			s[y] = make([]Cell, cols)
+			for x := range s[y] {
+				if y < len(old) && x < len(old[y]) {
+					s[y][x] = old[y][x]
+				} else {
+					s[y][x] = Cell{Rune: ' ', Fg: ColorDefault, Bg: ColorDefault}
+				}
+			}
+		}
+		return s
+	}
+	t.cells, t.saved = resize(t.cells), resize(t.saved)
+	t.rows, t.cols = rows, cols
+	t.top, t.bot = 0, rows-1
+	t.y, t.x = clamp(t.y, 0, rows-1), clamp(t.x, 0, cols-1)
+	t.wrapNext = false
+}
+
+func (t *vt) Write(p []byte) (int, error) {
+	for _, b := range p {
+		t.feed(b)
+	}
+	return len(p), nil
+}
+
+func (t *vt) feed(b byte) {
+	switch t.state {
+	case stEscape:
+		t.escape(b)
+		return
+	case stCharset:
+		t.g[t.inter[0]-'('] = b == '0'
+		t.state = stGround
+		return
+	case stCSI:
+		switch {
+		case b >= 0x30 && b <= 0x3f:
+			t.params = append(t.params, b)
+		case b >= 0x20 && b <= 0x2f:
+			t.inter = append(t.inter, b)
+		case b >= 0x40 && b <= 0x7e:
+			t.state = stGround
+			t.csi(b)
+		case b == 0x1b:
+			t.state = stEscape
+		}
+		return
+	case stString:
+		switch b {
+		case 0x07:
+			t.state = stGround
+		case 0x1b:
+			t.state = stStringEsc
+		}
+		return
+	case stStringEsc:
+		if b == '\\' {
+			t.state = stGround
+		} else {
+			t.state = stString
+		}
+		return
+	}
+
+	if len(t.utf) > 0 || b >= 0x80 {
+		t.utf = append(t.utf, b)
+		if !utf8.FullRune(t.utf) {
+			return
+		}
+		r, _ := utf8.DecodeRune(t.utf)
+		t.utf = t.utf[:0]
+		t.print(r)
+		return
+	}
+
+	switch b {
+	case 0x1b:
+		t.state = stEscape
+	case '\r':
+		t.x, t.wrapNext = 0, false
+	case '\n', '\v', '\f':
+		t.index()
+	case '\b':
+		if t.x > 0 {
+			t.x--
+		}
+		t.wrapNext = false
+	case '\t':
+		t.x = clamp((t.x/8+1)*8, 0, t.cols-1)
+		t.wrapNext = false
+	case 0x0e:
+		t.shift = 1
+	case 0x0f:
+		t.shift = 0
+	default:
+		if b >= 0x20 && b < 0x7f {
+			t.print(rune(b))
+		}
+	}
+}
+
+func (t *vt) escape(b byte) {
+	t.state = stGround
+	switch b {
+	case '[':
+		t.params, t.inter = t.params[:0], t.inter[:0]
+		t.state = stCSI
+	case ']', 'P', '_', '^', 'X':
+		t.state = stString
+	case '(', ')':
+		t.inter = append(t.inter[:0], b)
+		t.state = stCharset
+	case '7':
+		t.saveCursor()
+	case '8':
+		t.restoreCursor()
+	case 'D':
+		t.index()
+	case 'E':
+		t.x = 0
+		t.index()
+	case 'M':
+		t.reverseIndex()
+	case 'c':
+		t.reset()
+	}
+}
+
+func (t *vt) print(r rune) {
+	if t.g[t.shift] && r < 0x80 {
+		if u, ok := acsMap[r]; ok {
+			r = u
+		}
+	}
+	w := runeWidth(r)
+	if w == 0 {
+		t.combine(r)
+		return
+	}
+	if t.wrapNext || (w == 2 && t.x == t.cols-1) {
+		if t.autowrap {
+			t.x = 0
+			t.index()
+		}
+		t.wrapNext = false
+	}
+	if t.insert {
+		t.insertBlanks(w)
+	}
+	line := t.cells[t.y]
+	t.clearWide(t.y, t.x)
+	if w == 2 && t.x+1 < t.cols {
+		t.clearWide(t.y, t.x+1)
+	}
+	c := t.pen
+	c.Rune, c.Combining = r, nil
+	line[t.x] = c
+	if w == 2 && t.x+1 < t.cols {
+		c.Rune = 0
+		line[t.x+1] = c
+	}
+	t.lastRune = r
+	if t.x+w >= t.cols {
+		t.x = t.cols - 1
+		t.wrapNext = true
+	} else {
+		t.x += w
+	}
+}
+
+// combine attaches a zero-width character to the last character printed.
+func (t *vt) combine(r rune) {
+	y, x := t.y, t.x
+	if !t.wrapNext {
+		x--
+	}
+	if x > 0 && t.cells[y][x].Rune == 0 {
+		x--
+	}
+	if x < 0 {
+		return
+	}
+	t.cells[y][x].Combining = append(t.cells[y][x].Combining, r)
+}
+
+// clearWide blanks the other half of a wide character about to be
+// partially overwritten at y, x.
+func (t *vt) clearWide(y, x int) {
+	line := t.cells[y]
+	if line[x].Rune == 0 && x > 0 {
+		line[x-1] = t.blank()
+	}
+	if x+1 < t.cols && line[x+1].Rune == 0 {
+		line[x+1] = t.blank()
+	}
+}
+
+func (t *vt) index() {
+	t.wrapNext = false
+	if t.y == t.bot {
+		t.scrollUp(1)
+	} else if t.y < t.rows-1 {
+		t.y++
+	}
+}
+
+func (t *vt) reverseIndex() {
+	t.wrapNext = false
+	if t.y == t.top {
+		t.scrollDown(1)
+	} else if t.y > 0 {
+		t.y--
+	}
+}
+
+func (t *vt) scrollUp(n int) {
+	for i := 0; i < n; i++ {
+		copy(t.cells[t.top:t.bot+1], t.cells[t.top+1:t.bot+1])
+		t.cells[t.bot] = t.blankLine()
+	}
+}
+
+func (t *vt) scrollDown(n int) {
+	for i := 0; i < n; i++ {
+		copy(t.cells[t.top+1:t.bot+1], t.cells[t.top:t.bot])
+		t.cells[t.top] = t.blankLine()
+	}
+}
+
+func (t *vt) insertBlanks(n int) {
+	line := t.cells[t.y]
+	n = clamp(n, 0, t.cols-t.x)
+	copy(line[t.x+n:], line[t.x:])
+	for i := t.x; i < t.x+n; i++ {
+		line[i] = t.blank()
+	}
+}
+
+func (t *vt) deleteChars(n int) {
+	line := t.cells[t.y]
+	n = clamp(n, 0, t.cols-t.x)
+	copy(line[t.x:], line[t.x+n:])
+	for i := t.cols - n; i < t.cols; i++ {
+		line[i] = t.blank()
+	}
+}
+
+func (t *vt) eraseCells(y, from, to int) {
+	for x := clamp(from, 0, t.cols); x < clamp(to, 0, t.cols); x++ {
+		t.cells[y][x] = t.blank()
+	}
+}
+
+func (t *vt) saveCursor() {
+	t.savedCurs.y, t.savedCurs.x, t.savedCurs.pen = t.y, t.x, t.pen
+}
+
+func (t *vt) restoreCursor() {
+	t.y, t.x, t.pen = t.savedCurs.y, t.savedCurs.x, t.savedCurs.pen
+	t.wrapNext = false
+}
+
+func (t *vt) param(i, def int) int {
+	ps := splitParams(t.params)
+	if i >= len(ps) || ps[i] <= 0 {
+		return def
+	}
+	return ps[i]
+}
+
+func splitParams(b []byte) []int {
+	var ps []int
+	n, seen := 0, false
+	for _, c := range b {
+		switch {
+		case c >= '0' && c <= '9':
+			n = n*10 + int(c-'0')
+			seen = true
+		case c == ';' || c == ':':
+			if !seen {
+				n = -1
+			}
+			ps = append(ps, n)
+			n, seen = 0, false
+		}
+	}
+	if !seen {
+		n = -1
+	}
+	return append(ps, n)
+}
+
+func (t *vt) csi(final byte) {
+	private := len(t.params) > 0 && (t.params[0] == '?' || t.params[0] == '>' || t.params[0] == '=')
+	if private {
+		if final == 'h' || final == 'l' {
+			t.privateMode(final == 'h')
+		}
+		return
+	}
+	if len(t.inter) > 0 {
+		return
+	}
+	switch final {
+	case '@':
+		t.insertBlanks(t.param(0, 1))
+	case 'A':
+		t.y = clamp(t.y-t.param(0, 1), t.scrollTopFor(t.y), t.rows-1)
+	case 'B', 'e':
+		t.y = clamp(t.y+t.param(0, 1), 0, t.scrollBotFor(t.y))
+	case 'C', 'a':
+		t.x = clamp(t.x+t.param(0, 1), 0, t.cols-1)
+	case 'D':
+		t.x = clamp(t.x-t.param(0, 1), 0, t.cols-1)
+	case 'E':
+		t.y, t.x = clamp(t.y+t.param(0, 1), 0, t.rows-1), 0
+	case 'F':
+		t.y, t.x = clamp(t.y-t.param(0, 1), 0, t.rows-1), 0
+	case 'G', '`':
+		t.x = clamp(t.param(0, 1)-1, 0, t.cols-1)
+	case 'H', 'f':
+		t.y = clamp(t.param(0, 1)-1, 0, t.rows-1)
+		t.x = clamp(t.param(1, 1)-1, 0, t.cols-1)
+	case 'd':
+		t.y = clamp(t.param(0, 1)-1, 0, t.rows-1)
+	case 'J':
+		switch t.param(0, 0) {
+		case 0:
+			t.eraseCells(t.y, t.x, t.cols)
+			for y := t.y + 1; y < t.rows; y++ {
+				t.cells[y] = t.blankLine()
+			}
+		case 1:
+			t.eraseCells(t.y, 0, t.x+1)
+			for y := 0; y < t.y; y++ {
+				t.cells[y] = t.blankLine()
+			}
+		case 2, 3:
+			t.cells = t.blankScreen()
+		}
+	case 'K':
+		switch t.param(0, 0) {
+		case 0:
+			t.eraseCells(t.y, t.x, t.cols)
+		case 1:
+			t.eraseCells(t.y, 0, t.x+1)
+		case 2:
+			t.eraseCells(t.y, 0, t.cols)
+		}
+	case 'L', 'M':
+		if t.y < t.top || t.y > t.bot {
+			break
+		}
+		top := t.top
+		t.top = t.y
+		if final == 'L' {
+			t.scrollDown(clamp(t.param(0, 1), 0, t.bot-t.y+1))
+		} else {
+			t.scrollUp(clamp(t.param(0, 1), 0, t.bot-t.y+1))
+		}
+		t.top, t.x = top, 0
+	case 'P':
+		t.deleteChars(t.param(0, 1))
+	case 'S':
+		t.scrollUp(clamp(t.param(0, 1), 0, t.bot-t.top+1))
+	case 'T':
+		t.scrollDown(clamp(t.param(0, 1), 0, t.bot-t.top+1))
+	case 'X':
+		t.eraseCells(t.y, t.x, t.x+t.param(0, 1))
+	case 'b':
+		for i := t.param(0, 1); i > 0 && t.lastRune != 0; i-- {
+			t.print(t.lastRune)
+		}
+	case 'm':
+		t.sgr()
+	case 'r':
+		top, bot := t.param(0, 1)-1, t.param(1, t.rows)-1
+		if top < bot && bot < t.rows {
+			t.top, t.bot = top, bot
+			t.y, t.x = 0, 0
+		}
+	case 'h', 'l':
+		if t.param(0, 0) == 4 {
+			t.insert = final == 'h'
+		}
+	case 's':
+		t.saveCursor()
+	case 'u':
+		t.restoreCursor()
+	}
+	if final != 'b' && final != 'm' {
+		t.wrapNext = false
+	}
+}
+
+func (t *vt) scrollTopFor(y int) int {
+	if y >= t.top {
+		return t.top
+	}
+	return 0
+}
+
+func (t *vt) scrollBotFor(y int) int {
+	if y <= t.bot {
+		return t.bot
+	}
+	return t.rows - 1
+}
+
+func (t *vt) privateMode(set bool) {
+	for _, p := range splitParams(t.params[1:]) {
+		switch p {
+		case 7:
+			t.autowrap = set
+		case 25:
+			t.cursorOn = set
+		case 47, 1047, 1049:
+			if set && t.saved == nil {
+				if p == 1049 {
+					t.saveCursor()
+				}
+				t.saved, t.cells = t.cells, t.blankScreen()
+			} else if !set && t.saved != nil {
+				t.cells, t.saved = t.saved, nil
+				if p == 1049 {
+					t.restoreCursor()
+				}
+			}
+		}
+	}
+}
+
+func (t *vt) sgr() {
+	ps := splitParams(t.params)
+	for i := 0; i < len(ps); i++ {
+		switch p := ps[i]; {
+		case p <= 0:
+			t.pen.Attr, t.pen.Fg, t.pen.Bg = 0, ColorDefault, ColorDefault
+		case p == 1:
+			t.pen.Attr |= AttrBold
+		case p == 2:
+			t.pen.Attr |= AttrDim
+		case p == 3:
+			t.pen.Attr |= AttrItalic
+		case p == 4:
+			t.pen.Attr |= AttrUnderline
+		case p == 5:
+			t.pen.Attr |= AttrBlink
+		case p == 7:
+			t.pen.Attr |= AttrReverse
+		case p == 8:
+			t.pen.Attr |= AttrInvisible
+		case p == 9:
+			t.pen.Attr |= AttrStrike
+		case p == 21 || p == 22:
+			t.pen.Attr &^= AttrBold | AttrDim
+		case p == 23:
+			t.pen.Attr &^= AttrItalic
+		case p == 24:
+			t.pen.Attr &^= AttrUnderline
+		case p == 25:
+			t.pen.Attr &^= AttrBlink
+		case p == 27:
+			t.pen.Attr &^= AttrReverse
+		case p == 28:
+			t.pen.Attr &^= AttrInvisible
+		case p == 29:
+			t.pen.Attr &^= AttrStrike
+		case p >= 30 && p <= 37:
+			t.pen.Fg = p - 30
+		case p == 39:
+			t.pen.Fg = ColorDefault
+		case p >= 40 && p <= 47:
+			t.pen.Bg = p - 40
+		case p == 49:
+			t.pen.Bg = ColorDefault
+		case p >= 90 && p <= 97:
+			t.pen.Fg = p - 90 + 8
+		case p >= 100 && p <= 107:
+			t.pen.Bg = p - 100 + 8
+		case p == 38 || p == 48:
+			c, n := extendedColor(ps[i+1:])
+			i += n
+			if c != ColorDefault {
+				if p == 38 {
+					t.pen.Fg = c
+				} else {
+					t.pen.Bg = c
+				}
+			}
+		}
+	}
+}
+
+// extendedColor decodes the arguments of SGR 38 and 48, returning the color
+// and the number of parameters used.
+func extendedColor(ps []int) (int, int) {
+	if len(ps) >= 2 && ps[0] == 5 {
+		return ps[1], 2
+	}
+	if len(ps) >= 4 && ps[0] == 2 {
+		return ColorRGB | ps[1]<<16 | ps[2]<<8 | ps[3], 4
+	}
+	return ColorDefault, len(ps)
+}
+
+// acsMap translates the VT100 line drawing character set to Unicode.
+var acsMap = map[rune]rune{
+	'`': '◆', 'a': '▒', 'f': '°', 'g': '±', 'h': '░', 'i': '␋',
+	'j': '┘', 'k': '┐', 'l': '┌', 'm': '└', 'n': '┼', 'o': '⎺',
+	'p': '⎻', 'q': '─', 'r': '⎼', 's': '⎽', 't': '├', 'u': '┤',
+	'v': '┴', 'w': '┬', 'x': '│', 'y': '≤', 'z': '≥', '{': 'π',
+	'|': '≠', '}': '£', '~': '·', ',': '←', '+': '→', '.': '↓',
+	'-': '↑', '0': '█',
+}
+
+// wideRanges lists the East Asian wide and fullwidth ranges, plus the
+// emoji blocks, that terminals draw two columns wide.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115f}, {0x231a, 0x231b}, {0x2329, 0x232a}, {0x23e9, 0x23ec},
+	{0x23f0, 0x23f0}, {0x23f3, 0x23f3}, {0x25fd, 0x25fe}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267f, 0x267f}, {0x2693, 0x2693}, {0x26a1, 0x26a1},
+	{0x26aa, 0x26ab}, {0x26bd, 0x26be}, {0x26c4, 0x26c5}, {0x26ce, 0x26ce},
+	{0x26d4, 0x26d4}, {0x26ea, 0x26ea}, {0x26f2, 0x26f3}, {0x26f5, 0x26f5},
+	{0x26fa, 0x26fa}, {0x26fd, 0x26fd}, {0x2705, 0x2705}, {0x270a, 0x270b},
+	{0x2728, 0x2728}, {0x274c, 0x274c}, {0x274e, 0x274e}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27b0, 0x27b0}, {0x27bf, 0x27bf},
+	{0x2b1b, 0x2b1c}, {0x2b50, 0x2b50}, {0x2b55, 0x2b55}, {0x2e80, 0x303e},
+	{0x3041, 0x33ff}, {0x3400, 0x4dbf}, {0x4e00, 0x9fff}, {0xa000, 0xa4cf},
+	{0xa960, 0xa97f}, {0xac00, 0xd7a3}, {0xf900, 0xfaff}, {0xfe10, 0xfe19},
+	{0xfe30, 0xfe6f}, {0xff00, 0xff60}, {0xffe0, 0xffe6}, {0x16fe0, 0x18cff},
+	{0x1b000, 0x1b2ff}, {0x1f004, 0x1f004}, {0x1f0cf, 0x1f0cf}, {0x1f18e, 0x1f18e},
+	{0x1f191, 0x1f19a}, {0x1f200, 0x1f251}, {0x1f300, 0x1f64f}, {0x1f680, 0x1f6ff},
+	{0x1f7e0, 0x1f7eb}, {0x1f90c, 0x1f9ff}, {0x1fa70, 0x1faff}, {0x20000, 0x3fffd},
+}
+
+func runeWidth(r rune) int {
+	if r == 0 || unicode.In(r, unicode.Mn, unicode.Me) || r == 0x200b {
+		return 0
+	}
+	if r < 0x1100 {
+		return 1
+	}
+	for _, rg := range wideRanges {
+		if r < rg[0] {
+			break
+		}
+		if r <= rg[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+func clamp(v, min, max int) int {
+	if v > max {
+		v = max
+	}
+	if v < min {
+		v = min
+	}
+	return v
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
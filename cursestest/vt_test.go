@@ -0,0 +1,225 @@
+package cursestest
+
+import "testing"
+
+func render(rows, cols int, input string) *Grid {
+	t := newVT(rows, cols)
+	t.Write([]byte(input))
+	return t.grid()
+}
+
+func TestVT(t *testing.T) {
+	tests := []struct {
+		name       string
+		rows, cols int
+		input      string
+		text       string
+		styles     string
+	}{
+		{
+			name: "deferred wrap",
+			rows: 2, cols: 4,
+			input: "abcd\r\x1b[Cx",
+			text:  "axcd\n",
+		},
+		{
+			name: "autowrap",
+			rows: 2, cols: 4,
+			input: "abcde",
+			text:  "abcd\ne",
+		},
+		{
+			name: "autowrap off",
+			rows: 2, cols: 4,
+			input: "\x1b[?7labcde",
+			text:  "abce\n",
+		},
+		{
+			name: "cursor movement",
+			rows: 3, cols: 5,
+			input: "\x1b[2;3Hx\x1b[Ay\x1b[3dz\x1b[1G<",
+			text:  "   y\n  x\n<   z",
+		},
+		{
+			name: "erase line and display",
+			rows: 3, cols: 6,
+			input: "aaaaaa\r\nbbbbbb\r\ncccccc\x1b[2;3H\x1b[K\x1b[1;4H\x1b[1K\x1b[3;1H\x1b[J",
+			text:  "    aa\nbb\n",
+		},
+		{
+			name: "insert and delete characters",
+			rows: 1, cols: 6,
+			input: "abcdef\x1b[1;2H\x1b[2@\x1b[1;5H\x1b[P\x1b[1;1H\x1b[2X",
+			text:  "   bd",
+		},
+		{
+			name: "insert mode",
+			rows: 1, cols: 5,
+			input: "abc\r\x1b[4hxy\x1b[4lz",
+			text:  "xyzbc",
+		},
+		{
+			name: "repeat",
+			rows: 1, cols: 6,
+			input: "a\x1b[3b",
+			text:  "aaaa",
+		},
+		{
+			name: "SGR attributes and colors",
+			rows: 1, cols: 8,
+			input: "\x1b[1;31mab\x1b[22;4;42mc\x1b[0md\x1b[7;95me\x1b[27;39;49mf",
+			text:  "abcdef",
+			styles: "0:0-1 bold fg=1\n" +
+				"0:2-2 underline fg=1 bg=2\n" +
+				"0:4-4 reverse fg=13\n",
+		},
+		{
+			name: "SGR 256 and true color",
+			rows: 1, cols: 4,
+			input: "\x1b[38;5;200mx\x1b[48;2;1;2;255my\x1b[m",
+			text:  "xy",
+			styles: "0:0-0 fg=200\n" +
+				"0:1-1 fg=200 bg=#0102ff\n",
+		},
+		{
+			name: "line drawing set",
+			rows: 1, cols: 5,
+			input: "\x1b(0lqk\x1b(Bq",
+			text:  "┌─┐q",
+		},
+		{
+			name: "shift out to G1",
+			rows: 1, cols: 4,
+			input: "\x1b)0x\x0ex\x0fx",
+			text:  "x│x",
+		},
+		{
+			name: "wide characters",
+			rows: 1, cols: 6,
+			input: "漢a字",
+			text:  "漢a字",
+		},
+		{
+			name: "overwrite half of a wide character",
+			rows: 1, cols: 4,
+			input: "漢字\x1b[1;2Hx",
+			text:  " x字",
+		},
+		{
+			name: "wide character wraps at the margin",
+			rows: 2, cols: 3,
+			input: "ab漢",
+			text:  "ab\n漢",
+		},
+		{
+			name: "combining marks",
+			rows: 1, cols: 4,
+			input: "e\u0301x",
+			text:  "e\u0301x",
+		},
+		{
+			name: "scroll region",
+			rows: 4, cols: 3,
+			input: "top\x1b[4;1Hbot\x1b[2;3r\x1b[2;1H1\r\n2\r\n3",
+			text:  "top\n2\n3\nbot",
+		},
+		{
+			name: "reverse index in scroll region",
+			rows: 4, cols: 3,
+			input: "a\r\nb\r\nc\r\nd\x1b[2;3r\x1b[2;1H\x1bMx",
+			text:  "a\nx\nb\nd",
+		},
+		{
+			name: "insert and delete lines",
+			rows: 4, cols: 2,
+			input: "a\r\nb\r\nc\r\nd\x1b[2;1H\x1b[L\x1b[4;1H\x1b[M",
+			text:  "a\n\nb\n",
+		},
+		{
+			name: "alternate screen",
+			rows: 2, cols: 6,
+			input: "main\x1b[?1049h\x1b[2;1Halt\x1b[?1049lx",
+			text:  "mainx\n",
+		},
+		{
+			name: "alternate screen shown",
+			rows: 2, cols: 6,
+			input: "main\x1b[?1049h\x1b[Halt",
+			text:  "alt\n",
+		},
+		{
+			name: "OSC strings are ignored",
+			rows: 1, cols: 4,
+			input: "\x1b]0;title\x07a\x1b]2;x\x1b\\b",
+			text:  "ab",
+		},
+		{
+			name: "reset",
+			rows: 1, cols: 4,
+			input: "\x1b[1mab\x1bcc",
+			text:  "c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := render(tt.rows, tt.cols, tt.input)
+			if got := g.String(); got != tt.text {
+				t.Errorf("text = %q, want %q", got, tt.text)
+			}
+			if got := g.Styles(); got != tt.styles {
+				t.Errorf("styles = %q, want %q", got, tt.styles)
+			}
+		})
+	}
+}
+
+func TestVTCursor(t *testing.T) {
+	g := render(3, 5, "ab\x1b[?25l\x1b7\x1b[3;4Hx\x1b8")
+	if g.CursorY != 0 || g.CursorX != 2 || g.CursorVisible {
+		t.Errorf("cursor at %d,%d visible=%v, want 0,2 hidden", g.CursorY, g.CursorX, g.CursorVisible)
+	}
+}
+
+func TestVTResize(t *testing.T) {
+	v := newVT(3, 4)
+	v.Write([]byte("abcd\r\nefgh\r\nijkl"))
+	v.resize(2, 6)
+	g := v.grid()
+	if got, want := g.String(), "abcd\nefgh"; got != want {
+		t.Errorf("after shrinking rows: %q, want %q", got, want)
+	}
+	if g.CursorY != 1 || g.CursorX != 3 {
+		t.Errorf("cursor at %d,%d, want 1,3", g.CursorY, g.CursorX)
+	}
+	v.Write([]byte("\x1b[1;6Hz"))
+	if got, want := v.grid().Line(0), "abcd z"; got != want {
+		t.Errorf("after growing columns: %q, want %q", got, want)
+	}
+}
+
+func TestGridFind(t *testing.T) {
+	g := render(2, 8, "漢字\r\n ab\x1b[1mcd")
+	if y, x, ok := g.Find("cd"); !ok || y != 1 || x != 3 {
+		t.Errorf("Find(cd) = %d,%d,%v, want 1,3,true", y, x, ok)
+	}
+	if y, x, ok := g.Find("字"); !ok || y != 0 || x != 2 {
+		t.Errorf("Find(字) = %d,%d,%v, want 0,2,true", y, x, ok)
+	}
+	if _, _, ok := g.Find("zz"); ok {
+		t.Error("Find(zz) found a match")
+	}
+}
+
+func TestVTCells(t *testing.T) {
+	g := render(1, 6, "漢éx")
+	line := g.Cells[0]
+	if line[0].Rune != '漢' || line[1].Rune != 0 {
+		t.Errorf("wide character cells = %q %q, want 漢 and a continuation", line[0].Rune, line[1].Rune)
+	}
+	if line[2].Rune != 'e' || len(line[2].Combining) != 1 || line[2].Combining[0] != 0x301 {
+		t.Errorf("cell 2 = %q %q, want e with U+0301", line[2].Rune, line[2].Combining)
+	}
+	if line[3].Rune != 'x' {
+		t.Errorf("cell 3 = %q, want x", line[3].Rune)
+	}
+}
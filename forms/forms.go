@@ -3,6 +3,7 @@ package forms
 // #define _Bool int
 // #define _XOPEN_SOURCE_EXTENDED 1
 // #include <ncursesw/form.h>
+// #include <stdlib.h>
 // #cgo LDFLAGS: -lform -lncursesw
 import "C"
 
@@ -103,50 +104,64 @@ const (
 	MAX_FORM_COMMAND = C.MAX_FORM_COMMAND
 )
 
-type FormsError struct {
-	message string
-}
-
-func (fe FormsError) Error() string {
-	return fe.message
-}
+// FormsError is the error type of the package, a CursesError.
+type FormsError = CursesError
 
 /*
 * FIELD METHODS
  */
 
 func NewField(height int, width int, top int, left int, offscreen int, nbuf int) (*Field, error) {
+	CheckThread()
 	field := (*Field)(C.new_field(C.int(height), C.int(width), C.int(top), C.int(left), C.int(offscreen), C.int(nbuf)))
 	if field == nil {
-		return nil, FormsError{"NewField failed"}
+		return nil, FormsError{Op: "new_field", Code: ERR}
 	}
+	trackField(field)
 	return field, nil
 }
 
 func (field *Field) DupField(top int, left int) (*Field, error) {
+	CheckThread()
 	dup := (*Field)(C.dup_field((*C.FIELD)(field), C.int(top), C.int(left)))
 	if dup == nil {
-		return nil, FormsError{"Field.Dup failed"}
+		return nil, FormsError{Op: "dup_field", Code: ERR}
 	}
+	trackField(dup)
 	return dup, nil
 }
 
 func (field *Field) Link(top int, left int) (*Field, error) {
+	CheckThread()
 	link := (*Field)(C.link_field((*C.FIELD)(field), C.int(top), C.int(left)))
 	if link == nil {
-		return nil, FormsError{"Field.Link failed"}
+		return nil, FormsError{Op: "link_field", Code: ERR}
 	}
+	trackField(link)
 	return link, nil
 }
 
+// Free frees the field. The field must not be connected to a form, and
+// must not be used afterwards: a later field may get the same address, and
+// freeing the old one again would free it.
 func (field *Field) Free() error {
-	if C.free_field((*C.FIELD)(field)) != C.OK {
-		return FormsError{"Field.Free failed"}
+	CheckThread()
+	live.Lock()
+	defer live.Unlock()
+	st := live.fields[field]
+	if st == nil {
+		return nil
 	}
+	if err := CheckStatus("free_field", int(C.free_field((*C.FIELD)(field)))); err != nil {
+		return err
+	}
+	delete(live.fields, field)
+	st.data.Release()
 	return nil
 }
 
 func (field *Field) Info() (int, int, int, int, int, int, error) {
+	CheckThread()
 	var (
 		height    C.int
 		width     C.int
@@ -155,204 +170,344 @@ func (field *Field) Info() (int, int, int, int, int, int, error) {
 		offscreen C.int
 		nbuf      C.int
 	)
-	if C.field_info((*C.FIELD)(field), &height, &width, &top, &left, &offscreen, &nbuf) != C.OK {
-		return 0, 0, 0, 0, 0, 0, FormsError{"Field.Info failed"}
+	if err := CheckStatus("field_info", int(C.field_info((*C.FIELD)(field), &height, &width, &top, &left, &offscreen, &nbuf))); err != nil {
+		return 0, 0, 0, 0, 0, 0, err
 	}
 	return int(height), int(width), int(top), int(left), int(offscreen), int(nbuf), nil
 }
 
 func (field *Field) DynamicInfo() (int, int, int, error) {
+	CheckThread()
 	var (
 		prows C.int
 		pcols C.int
 		pmax  C.int
 	)
-	if C.dynamic_field_info((*C.FIELD)(field), &prows, &pcols, &pmax) != C.OK {
-		return 0, 0, 0, FormsError{"Field.DynamicInfo failed"}
+	if err := CheckStatus("dynamic_field_info", int(C.dynamic_field_info((*C.FIELD)(field), &prows, &pcols, &pmax))); err != nil {
+		return 0, 0, 0, err
 	}
 	return int(prows), int(pcols), int(pmax), nil
 }
 
-func (field *Field) SetMax(max int) bool {
-	return isOk(C.set_max_field((*C.FIELD)(field), C.int(max)))
+func (field *Field) SetMax(max int) error {
+	CheckThread()
+	return CheckStatus("set_max_field", int(C.set_max_field((*C.FIELD)(field), C.int(max))))
 }
 
-func (field *Field) Move(x int, y int) bool {
-	return isOk(C.move_field((*C.FIELD)(field), C.int(x), C.int(y)))
+func (field *Field) Move(x int, y int) error {
+	CheckThread()
+	return CheckStatus("move_field", int(C.move_field((*C.FIELD)(field), C.int(x), C.int(y))))
 }
 
-func (field *Field) SetNewPage(newPage bool) bool {
-	return isOk(C.set_new_page((*C.FIELD)(field), boolToInt(newPage)))
+func (field *Field) SetNewPage(newPage bool) error {
+	CheckThread()
+	return CheckStatus("set_new_page", int(C.set_new_page((*C.FIELD)(field), boolToInt(newPage))))
 }
 
-func (field *Field) SetJust(justMode int) bool {
-	return isOk(C.set_field_just((*C.FIELD)(field), C.int(justMode)))
+func (field *Field) SetJust(justMode int) error {
+	CheckThread()
+	return CheckStatus("set_field_just", int(C.set_field_just((*C.FIELD)(field), C.int(justMode))))
 }
 
 func (field *Field) Just() int {
+	CheckThread()
 	return (int)(C.field_just((*C.FIELD)(field)))
 }
 
-func (field *Field) SetFore(fore Chtype) bool {
-	return isOk(C.set_field_fore((*C.FIELD)(field), (C.chtype)(fore)))
+func (field *Field) SetFore(fore Chtype) error {
+	CheckThread()
+	return CheckStatus("set_field_fore", int(C.set_field_fore((*C.FIELD)(field), (C.chtype)(fore))))
 }
 
-func (field *Field) SetBack(back Chtype) bool {
-	return isOk(C.set_field_back((*C.FIELD)(field), (C.chtype)(back)))
+func (field *Field) SetBack(back Chtype) error {
+	CheckThread()
+	return CheckStatus("set_field_back", int(C.set_field_back((*C.FIELD)(field), (C.chtype)(back))))
 }
 
-func (field *Field) SetPad(pad int) bool {
-	return isOk(C.set_field_pad((*C.FIELD)(field), (C.bool)(C.int(pad))))
+func (field *Field) SetPad(pad int) error {
+	CheckThread()
+	return CheckStatus("set_field_pad", int(C.set_field_pad((*C.FIELD)(field), (C.bool)(C.int(pad)))))
 }
 
 func (field *Field) Pad() int {
+	CheckThread()
 	return (int)(C.field_pad((*C.FIELD)(field)))
 }
 
-func (field *Field) SetBuffer(ind int, message string) bool {
-	return isOk(C.set_field_buffer((*C.FIELD)(field), C.int(ind), C.CString(message)))
+func (field *Field) SetBuffer(ind int, message string) error {
+	CheckThread()
+	cmessage := C.CString(message)
+	defer C.free(unsafe.Pointer(cmessage))
+	return CheckStatus("set_field_buffer", int(C.set_field_buffer((*C.FIELD)(field), C.int(ind), cmessage)))
 }
 
-func (field *Field) SetStatus(status bool) bool {
-	return isOk(C.set_field_status((*C.FIELD)(field), boolToInt(status)))
+func (field *Field) SetStatus(status bool) error {
+	CheckThread()
+	return CheckStatus("set_field_status", int(C.set_field_status((*C.FIELD)(field), boolToInt(status))))
 }
 
-func (field *Field) SetOpts(attr FieldOptions) bool {
-	return isOk(C.set_field_opts((*C.FIELD)(field), (C.Field_Options)(attr)))
+func (field *Field) SetOpts(attr FieldOptions) error {
+	CheckThread()
+	return CheckStatus("set_field_opts", int(C.set_field_opts((*C.FIELD)(field), (C.Field_Options)(attr))))
 }
 
-func (field *Field) OptsOn(attr FieldOptions) bool {
-	return isOk(C.field_opts_on((*C.FIELD)(field), (C.Field_Options)(attr)))
+func (field *Field) OptsOn(attr FieldOptions) error {
+	CheckThread()
+	return CheckStatus("field_opts_on", int(C.field_opts_on((*C.FIELD)(field), (C.Field_Options)(attr))))
 }
 
-func (field *Field) OptsOff(attr FieldOptions) bool {
-	return isOk(C.field_opts_off((*C.FIELD)(field), (C.Field_Options)(attr)))
+func (field *Field) OptsOff(attr FieldOptions) error {
+	CheckThread()
+	return CheckStatus("field_opts_off", int(C.field_opts_off((*C.FIELD)(field), (C.Field_Options)(attr))))
 }
 
 func (field *Field) Buffer(ind int) string {
+	CheckThread()
 	buf := C.field_buffer((*C.FIELD)(field), C.int(ind))
 	return C.GoString(buf)
 }
 
 func (field *Field) Fore() Chtype {
+	CheckThread()
 	return (Chtype)(C.field_fore((*C.FIELD)(field)))
 }
 
 func (field *Field) Back() Chtype {
+	CheckThread()
 	return (Chtype)(C.field_back((*C.FIELD)(field)))
 }
 
 func (field *Field) NewPage() bool {
+	CheckThread()
 	return intToBool(C.new_page((*C.FIELD)(field)))
 }
 
 func (field *Field) Opts() FieldOptions {
+	CheckThread()
 	return (FieldOptions)(C.field_opts((*C.FIELD)(field)))
 }
 
 func (field *Field) Index() int {
+	CheckThread()
 	return (int)(C.field_index((*C.FIELD)(field)))
 }
 
+// SetData attaches v to the field, replacing any previous value; nil
+// clears it. The value is released when the field is freed.
+func (field *Field) SetData(v any) error {
+	CheckThread()
+	live.Lock()
+	defer live.Unlock()
+	st := live.fields[field]
+	if st == nil {
+		return FormsError{Op: "set_field_userptr", Code: E_BAD_ARGUMENT}
+	}
+	return st.data.Set(v, func(ptr unsafe.Pointer) error {
+		return CheckStatus("set_field_userptr", int(C.set_field_userptr((*C.FIELD)(field), ptr)))
+	})
+}
+
+// Data returns the value attached with SetData, or nil.
+func (field *Field) Data() any {
+	CheckThread()
+	live.Lock()
+	defer live.Unlock()
+	st := live.fields[field]
+	if st == nil {
+		return nil
+	}
+	return st.data.Get(C.field_userptr((*C.FIELD)(field)))
+}
+
 /*
 * FORM METHODS
  */
 
+// NewForm creates a form of fields, which are copied up to the first nil.
 func NewForm(fields []*Field) (*Form, error) {
-	form := (*Form)(C.new_form((**C.FIELD)(void(&fields[0]))))
+	CheckThread()
+	st := &formState{fields: fieldArray(fields)}
+	form := (*Form)(C.new_form(st.fields))
 	if form == nil {
-		return nil, FormsError{"NewForm failed"}
+		C.free(unsafe.Pointer(st.fields))
+		return nil, FormsError{Op: "new_form", Code: ERR}
 	}
+	live.Lock()
+	live.forms[form] = st
+	live.Unlock()
 	return form, nil
 }
 
 func (form *Form) CurrentField() *Field {
+	CheckThread()
 	return (*Field)(C.current_field((*C.FORM)(form)))
 }
 
 func (form *Form) DataAhead() bool {
+	CheckThread()
 	return intToBool(C.data_ahead((*C.FORM)(form)))
 }
 
 func (form *Form) DataBehind() bool {
+	CheckThread()
 	return intToBool(C.data_behind((*C.FORM)(form)))
 }
 
-func (form *Form) Free() bool {
-	return isOk(C.free_form((*C.FORM)(form)))
+// Free frees the form and then its fields. The form must not be posted, and
+// neither it nor its fields may be used afterwards.
+func (form *Form) Free() error {
+	CheckThread()
+	live.Lock()
+	st := live.forms[form]
+	live.Unlock()
+	if st == nil {
+		return nil
+	}
+	fields := form.connectedFields()
+	if err := CheckStatus("free_form", int(C.free_form((*C.FORM)(form)))); err != nil {
+		return err
+	}
+	live.Lock()
+	delete(live.forms, form)
+	live.Unlock()
+	st.release()
+	var first error
+	for _, field := range fields {
+		if err := field.Free(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
 }
 
-func (form *Form) SetFields(fields []*Field) bool {
-	return isOk(C.set_form_fields((*C.FORM)(form), (**C.FIELD)(void(&fields[0]))))
+// SetFields replaces the fields of the form, which are copied up to the
+// first nil. The previous fields are disconnected but not freed.
+func (form *Form) SetFields(fields []*Field) error {
+	CheckThread()
+	arr := fieldArray(fields)
+	if err := CheckStatus("set_form_fields", int(C.set_form_fields((*C.FORM)(form), arr))); err != nil {
+		C.free(unsafe.Pointer(arr))
+		return err
+	}
+	live.Lock()
+	if st := live.forms[form]; st != nil {
+		C.free(unsafe.Pointer(st.fields))
+		st.fields = arr
+	}
+	live.Unlock()
+	return nil
 }
 
 func (form *Form) FieldCount() int {
+	CheckThread()
 	return (int)(C.field_count((*C.FORM)(form)))
 }
 
-func (form *Form) SetCurrentField(field *Field) bool {
-	return isOk(C.set_current_field((*C.FORM)(form), (*C.FIELD)(field)))
+// SetData attaches v to the form, replacing any previous value; nil clears
+// it. The value is released when the form is freed.
+func (form *Form) SetData(v any) error {
+	CheckThread()
+	live.Lock()
+	defer live.Unlock()
+	st := live.forms[form]
+	if st == nil {
+		return FormsError{Op: "set_form_userptr", Code: E_BAD_ARGUMENT}
+	}
+	return st.data.Set(v, func(ptr unsafe.Pointer) error {
+		return CheckStatus("set_form_userptr", int(C.set_form_userptr((*C.FORM)(form), ptr)))
+	})
+}
+
+// Data returns the value attached with SetData, or nil.
+func (form *Form) Data() any {
+	CheckThread()
+	live.Lock()
+	defer live.Unlock()
+	st := live.forms[form]
+	if st == nil {
+		return nil
+	}
+	return st.data.Get(C.form_userptr((*C.FORM)(form)))
+}
+
+func (form *Form) SetCurrentField(field *Field) error {
+	CheckThread()
+	return CheckStatus("set_current_field", int(C.set_current_field((*C.FORM)(form), (*C.FIELD)(field))))
 }
 
-func (form *Form) SetPage(page int) bool {
-	return isOk(C.set_form_page((*C.FORM)(form), C.int(page)))
+func (form *Form) SetPage(page int) error {
+	CheckThread()
+	return CheckStatus("set_form_page", int(C.set_form_page((*C.FORM)(form), C.int(page))))
 }
 
 func (form *Form) Page() int {
+	CheckThread()
 	return (int)(C.form_page((*C.FORM)(form)))
 }
 
-func (form *Form) Post() bool {
-	return isOk(C.post_form((*C.FORM)(form)))
+func (form *Form) Post() error {
+	CheckThread()
+	return CheckStatus("post_form", int(C.post_form((*C.FORM)(form))))
 }
 
-func (form *Form) Unpost() bool {
-	return isOk(C.unpost_form((*C.FORM)(form)))
+func (form *Form) Unpost() error {
+	CheckThread()
+	return CheckStatus("unpost_form", int(C.unpost_form((*C.FORM)(form))))
 }
 
-func (form *Form) Drive(req int) bool {
-	return isOk(C.form_driver((*C.FORM)(form), C.int(req)))
+func (form *Form) Drive(req int) error {
+	CheckThread()
+	return CheckStatus("form_driver", int(C.form_driver((*C.FORM)(form), C.int(req))))
 }
 
 func (form *Form) Opts() FormOptions {
+	CheckThread()
 	return (FormOptions)(C.form_opts((*C.FORM)(form)))
 }
 
-func (form *Form) SetOpts(attr FormOptions) bool {
-	return isOk(C.set_form_opts((*C.FORM)(form), (C.Form_Options)(attr)))
+func (form *Form) SetOpts(attr FormOptions) error {
+	CheckThread()
+	return CheckStatus("set_form_opts", int(C.set_form_opts((*C.FORM)(form), (C.Form_Options)(attr))))
 }
 
-func (form *Form) OptsOn(attr FormOptions) bool {
-	return isOk(C.form_opts_on((*C.FORM)(form), (C.Form_Options)(attr)))
+func (form *Form) OptsOn(attr FormOptions) error {
+	CheckThread()
+	return CheckStatus("form_opts_on", int(C.form_opts_on((*C.FORM)(form), (C.Form_Options)(attr))))
 }
 
-func (form *Form) OptsOff(attr FormOptions) bool {
-	return isOk(C.form_opts_off((*C.FORM)(form), (C.Form_Options)(attr)))
+func (form *Form) OptsOff(attr FormOptions) error {
+	CheckThread()
+	return CheckStatus("form_opts_off", int(C.form_opts_off((*C.FORM)(form), (C.Form_Options)(attr))))
 }
 
 func (form *Form) Scale() (int, int, error) {
+	CheckThread()
 	var (
 		rows C.int
 		cols C.int
 	)
-	if C.scale_form((*C.FORM)(form), &rows, &cols) != C.OK {
-		return 0, 0, FormsError{"Form.Scale failed"}
+	if err := CheckStatus("scale_form", int(C.scale_form((*C.FORM)(form), &rows, &cols))); err != nil {
+		return 0, 0, err
 	}
 	return int(rows), int(cols), nil
 }
 
-func (form *Form) SetWin(window *Window) bool {
-	return isOk(C.set_form_win((*C.FORM)(form), (*C.WINDOW)(unsafe.Pointer(window))))
+func (form *Form) SetWin(window *Window) error {
+	CheckThread()
+	return CheckStatus("set_form_win", int(C.set_form_win((*C.FORM)(form), (*C.WINDOW)(unsafe.Pointer(window)))))
 }
 
-func (form *Form) SetSub(window *Window) bool {
-	return isOk(C.set_form_sub((*C.FORM)(form), (*C.WINDOW)(unsafe.Pointer(window))))
+func (form *Form) SetSub(window *Window) error {
+	CheckThread()
+	return CheckStatus("set_form_sub", int(C.set_form_sub((*C.FORM)(form), (*C.WINDOW)(unsafe.Pointer(window)))))
 }
 
 func (form *Form) Win() *Window {
+	CheckThread()
 	return (*Window)(unsafe.Pointer((C.form_win((*C.FORM)(form)))))
 }
 
 func (form *Form) Sub() *Window {
+	CheckThread()
 	return (*Window)(unsafe.Pointer((C.form_sub((*C.FORM)(form)))))
 }
@@ -0,0 +1,132 @@
+package forms
+
+// #define _Bool int
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/form.h>
+// #include <stdlib.h>
+import "C"
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	. "github.com/orofarne/gocurse/curses"
+)
+
+// fieldState is what the package keeps for a live field.
+type fieldState struct {
+	data UserData
+}
+
+// formState is what the package allocated for a live form: the
+// nil-terminated field array that ncurses keeps a pointer to.
+type formState struct {
+	fields **C.FIELD
+	data   UserData
+}
+
+// live tracks every field and form created and not yet freed.
+var live struct {
+	sync.Mutex
+	fields map[*Field]*fieldState
+	forms  map[*Form]*formState
+}
+
+func init() {
+	live.fields = make(map[*Field]*fieldState)
+	live.forms = make(map[*Form]*formState)
+}
+
+// Leaks reports how many fields and forms have been created and not yet
+// freed.
+func Leaks() (fields, forms int) {
+	live.Lock()
+	defer live.Unlock()
+	return len(live.fields), len(live.forms)
+}
+
+func trackField(field *Field) {
+	live.Lock()
+	live.fields[field] = new(fieldState)
+	live.Unlock()
+}
+
+func (s *formState) release() {
+	C.free(unsafe.Pointer(s.fields))
+	s.data.Release()
+}
+
+// fieldArray copies fields, up to the first nil, into a nil-terminated
+// array in C memory.
+func fieldArray(fields []*Field) **C.FIELD {
+	arr := (**C.FIELD)(C.calloc(C.size_t(len(fields)+1), C.size_t(unsafe.Sizeof((*C.FIELD)(nil)))))
+	cs := unsafe.Slice(arr, len(fields)+1)
+	for i, field := range fields {
+		if field == nil {
+			break
+		}
+		cs[i] = (*C.FIELD)(field)
+	}
+	return arr
+}
+
+// connectedFields returns the fields connected to form.
+func (form *Form) connectedFields() []*Field {
+	n := int(C.field_count((*C.FORM)(form)))
+	p := C.form_fields((*C.FORM)(form))
+	if n <= 0 || p == nil {
+		return nil
+	}
+	cs := unsafe.Slice(p, n)
+	fields := make([]*Field, n)
+	for i, c := range cs {
+		fields[i] = (*Field)(c)
+	}
+	return fields
+}
+
+// OwnedForm is a form that is freed, with its fields, once the OwnedForm is
+// no longer reachable. It has the methods of Form, but does not give the
+// form itself out, so that it is only ever freed through the OwnedForm.
+type OwnedForm struct {
+	*owned
+}
+
+// owned names Form so that OwnedForm can embed it unexported.
+type owned = Form
+
+// Own attaches a finalizer to form that frees it on the UI executor. After
+// Own, form must not be freed, or used, other than through the OwnedForm.
+// The finalizer never calls ncurses itself, so a form collected while no
+// executor is running, or while it is still posted, is leaked and stays
+// counted by Leaks.
+func Own(form *Form) *OwnedForm {
+	o := &OwnedForm{form}
+	runtime.SetFinalizer(o, func(o *OwnedForm) {
+		// Post drops the call, leaving the form leaked, if the
+		// executor closes first.
+		if e := UI(); e != nil {
+			e.Post(func() { o.owned.Free() })
+		}
+	})
+	return o
+}
+
+// Free frees the form now and drops the finalizer. Freeing it again does
+// nothing. If the form itself cannot be freed, for instance because it is
+// posted, it is kept along with the finalizer.
+func (o *OwnedForm) Free() error {
+	if o.owned == nil {
+		return nil
+	}
+	err := o.owned.Free()
+	live.Lock()
+	_, kept := live.forms[o.owned]
+	live.Unlock()
+	if !kept {
+		o.owned = nil
+		runtime.SetFinalizer(o, nil)
+	}
+	return err
+}
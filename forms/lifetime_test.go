@@ -0,0 +1,126 @@
+package forms_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+	"github.com/orofarne/gocurse/forms"
+)
+
+func newForm(t *testing.T) *forms.Form {
+	t.Helper()
+	var fields []*forms.Field
+	for i := 0; i < 2; i++ {
+		field, err := forms.NewField(1, 10, i, 0, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fields = append(fields, field)
+	}
+	form, err := forms.NewForm(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return form
+}
+
+func TestFree(t *testing.T) {
+	term, err := cursestest.New(10, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+
+	fields0, forms0 := forms.Leaks()
+	form := newForm(t)
+	if fields, n := forms.Leaks(); fields != fields0+2 || n != forms0+1 {
+		t.Errorf("Leaks() = %d, %d after NewForm; want %d, %d", fields, n, fields0+2, forms0+1)
+	}
+
+	if err := form.Post(); err != nil {
+		t.Fatal(err)
+	}
+	if err := form.Free(); !errors.Is(err, curses.ErrPosted) {
+		t.Errorf("Free of a posted form = %v, want ErrPosted", err)
+	}
+	if err := form.Unpost(); err != nil {
+		t.Fatal(err)
+	}
+	if err := form.Free(); err != nil {
+		t.Fatal(err)
+	}
+	if fields, n := forms.Leaks(); fields != fields0 || n != forms0 {
+		t.Errorf("Leaks() = %d, %d after Free; want %d, %d", fields, n, fields0, forms0)
+	}
+}
+
+func TestOwnedFree(t *testing.T) {
+	term, err := cursestest.New(10, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+
+	_, n0 := forms.Leaks()
+	o := forms.Own(newForm(t))
+	if err := o.Post(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Free(); !errors.Is(err, curses.ErrPosted) {
+		t.Errorf("Free of a posted form = %v, want ErrPosted", err)
+	}
+	if err := o.Unpost(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Free(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A form created after Free may reuse the address of the old one; a
+	// second Free must leave it alone.
+	other := newForm(t)
+	defer other.Free()
+	if err := o.Free(); err != nil {
+		t.Errorf("second Free = %v, want nil", err)
+	}
+	if _, n := forms.Leaks(); n != n0+1 {
+		t.Errorf("Leaks() = %d forms after a second Free, want %d", n, n0+1)
+	}
+}
+
+func TestOwn(t *testing.T) {
+	term, err := cursestest.New(10, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+
+	// Without an executor the finalizer leaves the form alone.
+	_, forms0 := forms.Leaks()
+	forms.Own(newForm(t))
+	for i := 0; i < 3; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, n := forms.Leaks(); n != forms0+1 {
+		t.Errorf("Leaks() = %d forms without an executor, want %d", n, forms0+1)
+	}
+
+	e := curses.NewExecutor()
+	defer e.Close()
+	e.Sync(func() { forms.Own(newForm(t)) })
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		e.Sync(func() {})
+		if _, n := forms.Leaks(); n == forms0+1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	_, n := forms.Leaks()
+	t.Errorf("Leaks() = %d forms with an executor, want %d", n, forms0+1)
+}
@@ -23,10 +23,3 @@ func intToBool(b C.int) bool {
 	}
 	return false
 }
-
-func isOk(ok C.int) bool {
-	if ok == C.OK {
-		return true
-	}
-	return false
-}
@@ -0,0 +1,66 @@
+package menus_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+)
+
+type payload struct{ n int }
+
+func TestData(t *testing.T) {
+	term, err := cursestest.New(10, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+
+	menu := newMenu(t)
+	item := menu.CurrentItem()
+	if v := item.Data(); v != nil {
+		t.Errorf("Data of a new item = %v, want nil", v)
+	}
+	if err := item.SetData(&payload{1}); err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := curses.DataAs[*payload](item); !ok || p.n != 1 {
+		t.Errorf("DataAs = %v, %v; want &{1}, true", p, ok)
+	}
+	if _, ok := curses.DataAs[string](item); ok {
+		t.Error("DataAs[string] succeeded")
+	}
+	if err := menu.SetData("menu"); err != nil {
+		t.Fatal(err)
+	}
+	if v := menu.Data(); v != "menu" {
+		t.Errorf("menu Data = %v, want menu", v)
+	}
+	if err := menu.SetData(nil); err != nil || menu.Data() != nil {
+		t.Errorf("SetData(nil) = %v, Data = %v; want nil, nil", err, menu.Data())
+	}
+
+	// Freeing the menu releases the values attached to it and its items.
+	released := make(chan struct{})
+	p := &payload{2}
+	runtime.SetFinalizer(p, func(*payload) { close(released) })
+	item.SetData(p)
+	p = nil
+	if err := menu.Free(); err != nil {
+		t.Fatal(err)
+	}
+	if item.Data() != nil {
+		t.Error("freed item still has data")
+	}
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		select {
+		case <-released:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Error("value attached to a freed item was not released")
+}
@@ -0,0 +1,134 @@
+package menus
+
+// #define _Bool int
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/menu.h>
+// #include <stdlib.h>
+import "C"
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	. "github.com/orofarne/gocurse/curses"
+)
+
+// itemState is what the package allocated for a live item. ncurses keeps
+// pointers to the name and description, so they live as long as the item.
+type itemState struct {
+	name, desc *C.char
+	data       UserData
+}
+
+// menuState is what the package allocated for a live menu: the
+// nil-terminated item array that ncurses keeps a pointer to.
+type menuState struct {
+	items **C.ITEM
+	data  UserData
+}
+
+// live tracks every item and menu created and not yet freed.
+var live struct {
+	sync.Mutex
+	items map[*Item]*itemState
+	menus map[*Menu]*menuState
+}
+
+func init() {
+	live.items = make(map[*Item]*itemState)
+	live.menus = make(map[*Menu]*menuState)
+}
+
+// Leaks reports how many items and menus have been created and not yet
+// freed.
+func Leaks() (items, menus int) {
+	live.Lock()
+	defer live.Unlock()
+	return len(live.items), len(live.menus)
+}
+
+func (s *itemState) release() {
+	C.free(unsafe.Pointer(s.name))
+	C.free(unsafe.Pointer(s.desc))
+	s.data.Release()
+}
+
+func (s *menuState) release() {
+	C.free(unsafe.Pointer(s.items))
+	s.data.Release()
+}
+
+// itemArray copies items, up to the first nil, into a nil-terminated array
+// in C memory.
+func itemArray(items []*Item) **C.ITEM {
+	arr := (**C.ITEM)(C.calloc(C.size_t(len(items)+1), C.size_t(unsafe.Sizeof((*C.ITEM)(nil)))))
+	cs := unsafe.Slice(arr, len(items)+1)
+	for i, item := range items {
+		if item == nil {
+			break
+		}
+		cs[i] = (*C.ITEM)(item)
+	}
+	return arr
+}
+
+// connectedItems returns the items connected to menu.
+func (menu *Menu) connectedItems() []*Item {
+	n := int(C.item_count((*C.MENU)(menu)))
+	p := C.menu_items((*C.MENU)(menu))
+	if n <= 0 || p == nil {
+		return nil
+	}
+	cs := unsafe.Slice(p, n)
+	items := make([]*Item, n)
+	for i, c := range cs {
+		items[i] = (*Item)(c)
+	}
+	return items
+}
+
+// OwnedMenu is a menu that is freed, with its items, once the OwnedMenu is
+// no longer reachable. It has the methods of Menu, but does not give the
+// menu itself out, so that it is only ever freed through the OwnedMenu.
+type OwnedMenu struct {
+	*owned
+}
+
+// owned names Menu so that OwnedMenu can embed it unexported.
+type owned = Menu
+
+// Own attaches a finalizer to menu that frees it on the UI executor. After
+// Own, menu must not be freed, or used, other than through the OwnedMenu.
+// The finalizer never calls ncurses itself, so a menu collected while no
+// executor is running, or while it is still posted, is leaked and stays
+// counted by Leaks.
+func Own(menu *Menu) *OwnedMenu {
+	o := &OwnedMenu{menu}
+	runtime.SetFinalizer(o, func(o *OwnedMenu) {
+		// Post drops the call, leaving the menu leaked, if the
+		// executor closes first.
+		if e := UI(); e != nil {
+			e.Post(func() { o.owned.Free() })
+		}
+	})
+	return o
+}
+
+// Free frees the menu now and drops the finalizer. Freeing it again does
+// nothing. If the menu itself cannot be freed, for instance because it is
+// posted, it is kept along with the finalizer.
+func (o *OwnedMenu) Free() error {
+	if o.owned == nil {
+		return nil
+	}
+	err := o.owned.Free()
+	live.Lock()
+	_, kept := live.menus[o.owned]
+	live.Unlock()
+	if !kept {
+		o.owned = nil
+		runtime.SetFinalizer(o, nil)
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package menus_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+	"github.com/orofarne/gocurse/menus"
+)
+
+func newMenu(t *testing.T) *menus.Menu {
+	t.Helper()
+	menu, err := menus.NewMenu([]*menus.Item{menus.NewItem("one", "1"), menus.NewItem("two", "2")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return menu
+}
+
+func TestFree(t *testing.T) {
+	term, err := cursestest.New(10, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+
+	items0, menus0 := menus.Leaks()
+	menu := newMenu(t)
+	if items, n := menus.Leaks(); items != items0+2 || n != menus0+1 {
+		t.Errorf("Leaks() = %d, %d after NewMenu; want %d, %d", items, n, items0+2, menus0+1)
+	}
+
+	if err := menu.Post(); err != nil {
+		t.Fatal(err)
+	}
+	if err := menu.Free(); !errors.Is(err, curses.ErrPosted) {
+		t.Errorf("Free of a posted menu = %v, want ErrPosted", err)
+	}
+	if err := menu.Unpost(); err != nil {
+		t.Fatal(err)
+	}
+	if err := menu.Free(); err != nil {
+		t.Fatal(err)
+	}
+	if items, n := menus.Leaks(); items != items0 || n != menus0 {
+		t.Errorf("Leaks() = %d, %d after Free; want %d, %d", items, n, items0, menus0)
+	}
+}
+
+func TestOwnedFree(t *testing.T) {
+	term, err := cursestest.New(10, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+
+	_, n0 := menus.Leaks()
+	o := menus.Own(newMenu(t))
+	if err := o.Post(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Free(); !errors.Is(err, curses.ErrPosted) {
+		t.Errorf("Free of a posted menu = %v, want ErrPosted", err)
+	}
+	if err := o.Unpost(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.Free(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A menu created after Free may reuse the address of the old one; a
+	// second Free must leave it alone.
+	other := newMenu(t)
+	defer other.Free()
+	if err := o.Free(); err != nil {
+		t.Errorf("second Free = %v, want nil", err)
+	}
+	if _, n := menus.Leaks(); n != n0+1 {
+		t.Errorf("Leaks() = %d menus after a second Free, want %d", n, n0+1)
+	}
+}
+
+func TestOwn(t *testing.T) {
+	term, err := cursestest.New(10, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+
+	// Without an executor the finalizer leaves the menu alone.
+	_, menus0 := menus.Leaks()
+	menus.Own(newMenu(t))
+	for i := 0; i < 3; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, n := menus.Leaks(); n != menus0+1 {
+		t.Errorf("Leaks() = %d menus without an executor, want %d", n, menus0+1)
+	}
+
+	e := curses.NewExecutor()
+	defer e.Close()
+	e.Sync(func() { menus.Own(newMenu(t)) })
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		e.Sync(func() {})
+		if _, n := menus.Leaks(); n == menus0+1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	_, n := menus.Leaks()
+	t.Errorf("Leaks() = %d menus with an executor, want %d", n, menus0+1)
+}
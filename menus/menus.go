@@ -3,6 +3,7 @@ package menus
 // #define _Bool int
 // #define _XOPEN_SOURCE_EXTENDED 1
 // #include <ncursesw/menu.h>
+// #include <stdlib.h>
 // #cgo LDFLAGS: -lmenu -lncursesw
 import "C"
 
@@ -47,171 +48,332 @@ const (
 	MAX_MENU_COMMAND = C.MAX_MENU_COMMAND
 )
 
-type MenusError struct {
-	message string
-}
-
-func (e MenusError) Error() string {
-	return e.message
-}
+// MenusError is the error type of the package, a CursesError.
+type MenusError = CursesError
 
 /*
  * Menu functions
  */
 
 func (menu *Menu) CurrentItem() *Item {
+	CheckThread()
 	return (*Item)(C.current_item((*C.MENU)(menu)))
 }
 
+// NewItem creates an item. Its name and description are freed with it.
 func NewItem(name string, desc string) *Item {
-	return (*Item)(C.new_item(C.CString(name), C.CString(desc)))
+	CheckThread()
+	st := &itemState{name: C.CString(name), desc: C.CString(desc)}
+	item := (*Item)(C.new_item(st.name, st.desc))
+	if item == nil {
+		st.release()
+		return nil
+	}
+	live.Lock()
+	live.items[item] = st
+	live.Unlock()
+	return item
 }
 
+// NewMenu creates a menu of items, which are copied up to the first nil.
 func NewMenu(items []*Item) (*Menu, error) {
-	menu := (*Menu)(C.new_menu((**C.ITEM)(void(&items[0]))))
+	CheckThread()
+	st := &menuState{items: itemArray(items)}
+	menu := (*Menu)(C.new_menu(st.items))
 	if menu == nil {
-		return nil, MenusError{"NewMenu failed"}
+		C.free(unsafe.Pointer(st.items))
+		return nil, MenusError{Op: "new_menu", Code: ERR}
 	}
+	live.Lock()
+	live.menus[menu] = st
+	live.Unlock()
 	return menu, nil
 }
 
+// SetItems replaces the items of the menu, which are copied up to the
+// first nil. The previous items are disconnected but not freed.
+func (menu *Menu) SetItems(items []*Item) error {
+	CheckThread()
+	arr := itemArray(items)
+	if err := CheckStatus("set_menu_items", int(C.set_menu_items((*C.MENU)(menu), arr))); err != nil {
+		C.free(unsafe.Pointer(arr))
+		return err
+	}
+	live.Lock()
+	if st := live.menus[menu]; st != nil {
+		C.free(unsafe.Pointer(st.items))
+		st.items = arr
+	}
+	live.Unlock()
+	return nil
+}
+
 func (item *Item) Opts() ItemOptions {
+	CheckThread()
 	return ItemOptions(C.item_opts((*C.ITEM)(item)))
 }
 
 func (menu *Menu) Opts() MenuOptions {
+	CheckThread()
 	return MenuOptions(C.menu_opts((*C.MENU)(menu)))
 }
 
 func (item *Item) Description() string {
+	CheckThread()
 	return C.GoString(C.item_description((*C.ITEM)(item)))
 }
 
 func (item *Item) Name() string {
+	CheckThread()
 	return C.GoString(C.item_name((*C.ITEM)(item)))
 }
 
 func (menu *Menu) Mark() string {
+	CheckThread()
 	return C.GoString(C.menu_mark((*C.MENU)(menu)))
 }
 
-func (menu *Menu) SetMark(mark string) bool {
-	return isOk(C.set_menu_mark((*C.MENU)(menu), C.CString(mark)))
+func (menu *Menu) SetMark(mark string) error {
+	CheckThread()
+	cmark := C.CString(mark)
+	defer C.free(unsafe.Pointer(cmark))
+	return CheckStatus("set_menu_mark", int(C.set_menu_mark((*C.MENU)(menu), cmark)))
 }
 
 func (menu *Menu) Pattern() string {
+	CheckThread()
 	return C.GoString(C.menu_pattern((*C.MENU)(menu)))
 }
 
 func (menu *Menu) Back() Chtype {
+	CheckThread()
 	return Chtype(C.menu_back((*C.MENU)(menu)))
 }
 
 func (menu *Menu) Fore() Chtype {
+	CheckThread()
 	return Chtype(C.menu_fore((*C.MENU)(menu)))
 }
 
 func (menu *Menu) Grey() Chtype {
+	CheckThread()
 	return Chtype(C.menu_grey((*C.MENU)(menu)))
 }
 
-func (item *Item) Free() bool {
-	return isOk(C.free_item((*C.ITEM)(item)))
-}
-
-func (menu *Menu) Free() bool {
-	return isOk(C.free_menu((*C.MENU)(menu)))
+// Free frees the item and its strings. The item must not be connected to
+// a menu, and must not be used afterwards: a later item may get the same
+// address, and freeing the old one again would free it.
+func (item *Item) Free() error {
+	CheckThread()
+	live.Lock()
+	defer live.Unlock()
+	st, ok := live.items[item]
+	if !ok {
+		return nil
+	}
+	if err := CheckStatus("free_item", int(C.free_item((*C.ITEM)(item)))); err != nil {
+		return err
+	}
+	delete(live.items, item)
+	st.release()
+	return nil
+}
+
+// Free frees the menu and then its items. The menu must not be posted, and
+// neither it nor its items may be used afterwards.
+func (menu *Menu) Free() error {
+	CheckThread()
+	live.Lock()
+	st := live.menus[menu]
+	live.Unlock()
+	if st == nil {
+		return nil
+	}
+	items := menu.connectedItems()
+	if err := CheckStatus("free_menu", int(C.free_menu((*C.MENU)(menu)))); err != nil {
+		return err
+	}
+	live.Lock()
+	delete(live.menus, menu)
+	live.Unlock()
+	st.release()
+	var first error
+	for _, item := range items {
+		if err := item.Free(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
 }
 
 func (menu *Menu) ItemCount() int {
+	CheckThread()
 	return int(C.item_count((*C.MENU)(menu)))
 }
 
 func (item *Item) Index() int {
+	CheckThread()
 	return int(C.item_index((*C.ITEM)(item)))
 }
 
-func (item *Item) OptsOn(opt ItemOptions) bool {
-	return isOk(C.item_opts_on((*C.ITEM)(item), (C.Item_Options)(opt)))
+func (item *Item) OptsOn(opt ItemOptions) error {
+	CheckThread()
+	return CheckStatus("item_opts_on", int(C.item_opts_on((*C.ITEM)(item), (C.Item_Options)(opt))))
 }
 
-func (item *Item) OptsOff(opt ItemOptions) bool {
-	return isOk(C.item_opts_off((*C.ITEM)(item), (C.Item_Options)(opt)))
+func (item *Item) OptsOff(opt ItemOptions) error {
+	CheckThread()
+	return CheckStatus("item_opts_off", int(C.item_opts_off((*C.ITEM)(item), (C.Item_Options)(opt))))
 }
 
-func (menu *Menu) Drive(req int) bool {
-	return isOk(C.menu_driver((*C.MENU)(menu), C.int(req)))
+func (menu *Menu) Drive(req int) error {
+	CheckThread()
+	return CheckStatus("menu_driver", int(C.menu_driver((*C.MENU)(menu), C.int(req))))
 }
 
-func (menu *Menu) OptsOn(opt MenuOptions) bool {
-	return isOk(C.menu_opts_on((*C.MENU)(menu), (C.Menu_Options)(opt)))
+func (menu *Menu) OptsOn(opt MenuOptions) error {
+	CheckThread()
+	return CheckStatus("menu_opts_on", int(C.menu_opts_on((*C.MENU)(menu), (C.Menu_Options)(opt))))
 }
 
-func (menu *Menu) OptsOff(opt MenuOptions) bool {
-	return isOk(C.menu_opts_off((*C.MENU)(menu), (C.Menu_Options)(opt)))
+func (menu *Menu) OptsOff(opt MenuOptions) error {
+	CheckThread()
+	return CheckStatus("menu_opts_off", int(C.menu_opts_off((*C.MENU)(menu), (C.Menu_Options)(opt))))
 }
 
 func (menu *Menu) Pad() int {
+	CheckThread()
 	return int(C.menu_pad((*C.MENU)(menu)))
 }
 
-func (menu *Menu) Post() bool {
-	return isOk(C.post_menu((*C.MENU)(menu)))
+func (menu *Menu) Post() error {
+	CheckThread()
+	return CheckStatus("post_menu", int(C.post_menu((*C.MENU)(menu))))
 }
 
-func (menu *Menu) Unpost() bool {
-	return isOk(C.unpost_menu((*C.MENU)(menu)))
+func (menu *Menu) Unpost() error {
+	CheckThread()
+	return CheckStatus("unpost_menu", int(C.unpost_menu((*C.MENU)(menu))))
 }
 
-func (menu *Menu) SetCurrentItem(item *Item) bool {
-	return isOk(C.set_current_item((*C.MENU)(menu), (*C.ITEM)(item)))
+func (menu *Menu) SetCurrentItem(item *Item) error {
+	CheckThread()
+	return CheckStatus("set_current_item", int(C.set_current_item((*C.MENU)(menu), (*C.ITEM)(item))))
 }
 
-func (menu *Menu) SetWin(win *Window) bool {
-	return isOk(C.set_menu_win((*C.MENU)(menu), (*C.WINDOW)(unsafe.Pointer(win))))
+func (menu *Menu) SetWin(win *Window) error {
+	CheckThread()
+	return CheckStatus("set_menu_win", int(C.set_menu_win((*C.MENU)(menu), (*C.WINDOW)(unsafe.Pointer(win)))))
 }
 
 func (menu *Menu) Win() *Window {
+	CheckThread()
 	return (*Window)(unsafe.Pointer((C.menu_win((*C.MENU)(menu)))))
 }
 
-func (menu *Menu) SetSub(win *Window) bool {
-	return isOk(C.set_menu_sub((*C.MENU)(menu), (*C.WINDOW)(unsafe.Pointer(win))))
+func (menu *Menu) SetSub(win *Window) error {
+	CheckThread()
+	return CheckStatus("set_menu_sub", int(C.set_menu_sub((*C.MENU)(menu), (*C.WINDOW)(unsafe.Pointer(win)))))
 }
 
 func (menu *Menu) Sub() *Window {
+	CheckThread()
 	return (*Window)(unsafe.Pointer((C.menu_sub((*C.MENU)(menu)))))
 }
 
 func (item *Item) Value() bool {
+	CheckThread()
 	return intToBool(C.item_value((*C.ITEM)(item)))
 }
 
 func (menu *Menu) Scale() (int, int, error) {
+	CheckThread()
 	var (
 		rows C.int
 		cols C.int
 	)
-	if C.scale_menu((*C.MENU)(menu), &rows, &cols) != C.OK {
-		return 0, 0, MenusError{"Form.Scale failed"}
+	if err := CheckStatus("scale_menu", int(C.scale_menu((*C.MENU)(menu), &rows, &cols))); err != nil {
+		return 0, 0, err
 	}
 	return int(rows), int(cols), nil
 }
 
 func (item *Item) Visible() bool {
+	CheckThread()
 	return intToBool(C.item_visible((*C.ITEM)(item)))
 }
 
 func (menu *Menu) Format(rows int, cols int) {
+	CheckThread()
 	cRows := C.int(rows)
 	cCols := C.int(cols)
 	C.menu_format((*C.MENU)(menu), &cRows, &cCols)
 }
 
-func (item *Item) SetUserPtr(ptr unsafe.Pointer) bool {
-	return isOk(C.set_item_userptr((*C.ITEM)(item), ptr))
+// SetUserPtr sets the raw user pointer of the item.
+//
+// Deprecated: ptr must not point to Go memory; use SetData to attach a Go
+// value.
+func (item *Item) SetUserPtr(ptr unsafe.Pointer) error {
+	CheckThread()
+	return CheckStatus("set_item_userptr", int(C.set_item_userptr((*C.ITEM)(item), ptr)))
 }
 
 func (item *Item) UserPtr() unsafe.Pointer {
+	CheckThread()
 	return unsafe.Pointer(C.item_userptr((*C.ITEM)(item)))
 }
+
+// SetData attaches v to the item, replacing any previous value; nil clears
+// it. The value is released when the item is freed.
+func (item *Item) SetData(v any) error {
+	CheckThread()
+	live.Lock()
+	defer live.Unlock()
+	st := live.items[item]
+	if st == nil {
+		return MenusError{Op: "set_item_userptr", Code: E_BAD_ARGUMENT}
+	}
+	return st.data.Set(v, func(ptr unsafe.Pointer) error {
+		return CheckStatus("set_item_userptr", int(C.set_item_userptr((*C.ITEM)(item), ptr)))
+	})
+}
+
+// Data returns the value attached with SetData, or nil.
+func (item *Item) Data() any {
+	CheckThread()
+	live.Lock()
+	defer live.Unlock()
+	st := live.items[item]
+	if st == nil {
+		return nil
+	}
+	return st.data.Get(C.item_userptr((*C.ITEM)(item)))
+}
+
+// SetData attaches v to the menu, replacing any previous value; nil clears
+// it. The value is released when the menu is freed.
+func (menu *Menu) SetData(v any) error {
+	CheckThread()
+	live.Lock()
+	defer live.Unlock()
+	st := live.menus[menu]
+	if st == nil {
+		return MenusError{Op: "set_menu_userptr", Code: E_BAD_ARGUMENT}
+	}
+	return st.data.Set(v, func(ptr unsafe.Pointer) error {
+		return CheckStatus("set_menu_userptr", int(C.set_menu_userptr((*C.MENU)(menu), ptr)))
+	})
+}
+
+// Data returns the value attached with SetData, or nil.
+func (menu *Menu) Data() any {
+	CheckThread()
+	live.Lock()
+	defer live.Unlock()
+	st := live.menus[menu]
+	if st == nil {
+		return nil
+	}
+	return st.data.Get(C.menu_userptr((*C.MENU)(menu)))
+}
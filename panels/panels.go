@@ -8,59 +8,139 @@ import "C"
 
 import (
 	. "github.com/orofarne/gocurse/curses"
+	"sync"
 	"unsafe"
 )
 
 type Panel C.PANEL
 
+// panelData holds the values attached to panels with SetData.
+var panelData struct {
+	sync.Mutex
+	m map[*Panel]*UserData
+}
+
+// PanelsError is the error type of the package, a CursesError.
+type PanelsError = CursesError
+
 func (panel *Panel) Window() *Window {
+	CheckThread()
 	return (*Window)(unsafe.Pointer((C.panel_window((*C.PANEL)(panel)))))
 }
 
 func UpdatePanels() {
+	CheckThread()
 	C.update_panels()
 }
 
-func (panel *Panel) Hide() bool {
-	return isOk(C.hide_panel((*C.PANEL)(panel)))
+func (panel *Panel) Hide() error {
+	CheckThread()
+	return CheckStatus("hide_panel", int(C.hide_panel((*C.PANEL)(panel))))
 }
 
-func (panel *Panel) Show() bool {
-	return isOk(C.show_panel((*C.PANEL)(panel)))
+func (panel *Panel) Show() error {
+	CheckThread()
+	return CheckStatus("show_panel", int(C.show_panel((*C.PANEL)(panel))))
 }
 
-func (panel *Panel) Del() bool {
-	return isOk(C.del_panel((*C.PANEL)(panel)))
+// Del deletes the panel and releases its data.
+func (panel *Panel) Del() error {
+	CheckThread()
+	if err := CheckStatus("del_panel", int(C.del_panel((*C.PANEL)(panel)))); err != nil {
+		return err
+	}
+	panelData.Lock()
+	if d := panelData.m[panel]; d != nil {
+		d.Release()
+		delete(panelData.m, panel)
+	}
+	panelData.Unlock()
+	return nil
 }
 
-func (panel *Panel) Top() bool {
-	return isOk(C.top_panel((*C.PANEL)(panel)))
+func (panel *Panel) Top() error {
+	CheckThread()
+	return CheckStatus("top_panel", int(C.top_panel((*C.PANEL)(panel))))
 }
 
-func (panel *Panel) Bottom() bool {
-	return isOk(C.bottom_panel((*C.PANEL)(panel)))
+func (panel *Panel) Bottom() error {
+	CheckThread()
+	return CheckStatus("bottom_panel", int(C.bottom_panel((*C.PANEL)(panel))))
 }
 
 func NewPanel(win *Window) *Panel {
+	CheckThread()
 	return (*Panel)(C.new_panel((*C.WINDOW)(unsafe.Pointer((win)))))
 }
 
 func (panel *Panel) Above() *Panel {
+	CheckThread()
 	return (*Panel)(C.panel_above((*C.PANEL)(panel)))
 }
 
 func (panel *Panel) Below() *Panel {
+	CheckThread()
 	return (*Panel)(C.panel_below((*C.PANEL)(panel)))
 }
 
-func (panel *Panel) Move(y, x int) bool {
-	return isOk(C.move_panel((*C.PANEL)(panel), C.int(y), C.int(x)))
+func (panel *Panel) Move(y, x int) error {
+	CheckThread()
+	return CheckStatus("move_panel", int(C.move_panel((*C.PANEL)(panel), C.int(y), C.int(x))))
+}
+
+func (panel *Panel) Replace(win *Window) error {
+	CheckThread()
+	return CheckStatus("replace_panel", int(C.replace_panel((*C.PANEL)(panel), (*C.WINDOW)(unsafe.Pointer((win))))))
 }
 
-func (panel *Panel) Replace(win *Window) bool {
-	return isOk(C.replace_panel((*C.PANEL)(panel), (*C.WINDOW)(unsafe.Pointer((win)))))
+// SetData attaches v to the panel, replacing any previous value; nil
+// clears it. The value is released when the panel is deleted.
+func (panel *Panel) SetData(v any) error {
+	CheckThread()
+	panelData.Lock()
+	defer panelData.Unlock()
+	if panelData.m == nil {
+		panelData.m = make(map[*Panel]*UserData)
+	}
+	d := panelData.m[panel]
+	if d == nil {
+		d = new(UserData)
+		panelData.m[panel] = d
+	}
+	return d.Set(v, func(ptr unsafe.Pointer) error {
+		return CheckStatus("set_panel_userptr", int(C.set_panel_userptr((*C.PANEL)(panel), ptr)))
+	})
+}
+
+// Data returns the value attached with SetData, or nil.
+func (panel *Panel) Data() any {
+	CheckThread()
+	panelData.Lock()
+	defer panelData.Unlock()
+	d := panelData.m[panel]
+	if d == nil {
+		return nil
+	}
+	return d.Get(unsafe.Pointer(C.panel_userptr((*C.PANEL)(panel))))
 }
 
 func (panel *Panel) Hidden() bool {
+	CheckThread()
 	return intToBool(C.panel_hidden((*C.PANEL)(panel)))
 }
+
+// OnResize registers layout to compute the new position and size of the
+// panel's window whenever the terminal is resized (see curses.HandleResize).
+func (panel *Panel) OnResize(layout func(ResizeEvent) Rect) (cancel func()) {
+	return OnResize(func(ev ResizeEvent) error {
+		r := layout(ev)
+		win := panel.Window()
+		if err := win.Resize(r.Rows, r.Cols); err != nil {
+			return err
+		}
+		if err := panel.Replace(win); err != nil {
+			return err
+		}
+		return panel.Move(r.Y, r.X)
+	})
+}
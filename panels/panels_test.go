@@ -0,0 +1,59 @@
+package panels_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/orofarne/gocurse/curses"
+	"github.com/orofarne/gocurse/cursestest"
+	"github.com/orofarne/gocurse/panels"
+)
+
+type payload struct{ n int }
+
+func TestData(t *testing.T) {
+	term, err := cursestest.New(10, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+
+	win, err := curses.Newwin(3, 10, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer win.Del()
+	panel := panels.NewPanel(win)
+	if v := panel.Data(); v != nil {
+		t.Errorf("Data of a new panel = %v, want nil", v)
+	}
+	if err := panel.SetData(&payload{1}); err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := curses.DataAs[*payload](panel); !ok || p.n != 1 {
+		t.Errorf("DataAs = %v, %v; want &{1}, true", p, ok)
+	}
+	if err := panel.SetData(nil); err != nil || panel.Data() != nil {
+		t.Errorf("SetData(nil) = %v, Data = %v; want nil, nil", err, panel.Data())
+	}
+
+	// Deleting the panel releases its value.
+	released := make(chan struct{})
+	p := &payload{2}
+	runtime.SetFinalizer(p, func(*payload) { close(released) })
+	panel.SetData(p)
+	p = nil
+	if err := panel.Del(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		select {
+		case <-released:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Error("value attached to a deleted panel was not released")
+}
@@ -0,0 +1,159 @@
+// Package terminfo gives access to the terminfo capabilities of the current
+// terminal, for output that curses itself does not provide. It shares the
+// terminal state of curses, so its functions belong on the UI executor too
+// and are covered by curses.SetThreadCheck.
+package terminfo
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+// #include <ncursesw/term.h>
+// #include <stdlib.h>
+// #cgo LDFLAGS: -lncursesw
+// static char *_tiparm9(const char *s, int a1, int a2, int a3, int a4, int a5, int a6, int a7, int a8, int a9) {
+// 	return tiparm(s, a1, a2, a3, a4, a5, a6, a7, a8, a9);
+// }
+// static int _is_not_string(const char *s) {
+// 	return s == (const char *)-1;
+// }
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/orofarne/gocurse/curses"
+)
+
+var (
+	// ErrAbsent is returned for a capability the terminal description does
+	// not define, or cancels. ncurses reports both the same way, so the two
+	// cannot be told apart.
+	ErrAbsent = errors.New("capability absent")
+	// ErrNotCapability is returned for a name that is not a capability of
+	// the requested type.
+	ErrNotCapability = errors.New("not a capability of this type")
+)
+
+// The values tigetnum returns for an absent or cancelled capability and for
+// a name that is not a numeric capability; term.h does not export them.
+const (
+	absentNumeric = -1
+	notNumeric    = -2
+)
+
+// TerminfoError describes a failed lookup of a capability. Use errors.Is
+// with ErrAbsent or ErrNotCapability to tell the reasons apart.
+type TerminfoError struct {
+	Capability string
+	Err        error
+}
+
+func (e TerminfoError) Error() string {
+	return "terminfo: " + e.Capability + ": " + e.Err.Error()
+}
+
+func (e TerminfoError) Unwrap() error {
+	return e.Err
+}
+
+// Setupterm loads the description of term, or of $TERM if term is empty,
+// for the terminal on file descriptor fd. It is only needed when curses has
+// not been started with Initscr or Newterm.
+func Setupterm(term string, fd int) error {
+	curses.CheckThread()
+	var s *C.char
+	if term != "" {
+		s = C.CString(term)
+		defer C.free(unsafe.Pointer(s))
+	}
+	var errret C.int
+	if C.setupterm(s, C.int(fd), &errret) == C.ERR {
+		switch errret {
+		case 0:
+			return TerminfoError{term, errors.New("terminal not found")}
+		case -1:
+			return TerminfoError{term, errors.New("terminfo database not found")}
+		}
+		return TerminfoError{term, errors.New("setupterm failed")}
+	}
+	return nil
+}
+
+// Tigetstr returns the value of the string capability name, e.g. "smkx".
+func Tigetstr(name string) (string, error) {
+	curses.CheckThread()
+	s := C.CString(name)
+	defer C.free(unsafe.Pointer(s))
+	v := C.tigetstr(s)
+	if v == nil {
+		return "", TerminfoError{name, ErrAbsent}
+	}
+	if C._is_not_string(v) != 0 {
+		return "", TerminfoError{name, ErrNotCapability}
+	}
+	return C.GoString(v), nil
+}
+
+// Tigetnum returns the value of the numeric capability name, e.g. "colors".
+func Tigetnum(name string) (int, error) {
+	curses.CheckThread()
+	s := C.CString(name)
+	defer C.free(unsafe.Pointer(s))
+	switch v := C.tigetnum(s); v {
+	case absentNumeric:
+		return 0, TerminfoError{name, ErrAbsent}
+	case notNumeric:
+		return 0, TerminfoError{name, ErrNotCapability}
+	default:
+		return int(v), nil
+	}
+}
+
+// Tigetflag returns the value of the boolean capability name, e.g. "am".
+// An absent flag is false; ErrNotCapability is returned if name is not a
+// boolean capability.
+func Tigetflag(name string) (bool, error) {
+	curses.CheckThread()
+	s := C.CString(name)
+	defer C.free(unsafe.Pointer(s))
+	switch v := C.tigetflag(s); {
+	case v < 0:
+		return false, TerminfoError{name, ErrNotCapability}
+	default:
+		return v != 0, nil
+	}
+}
+
+// Tiparm instantiates the parameterized capability str, as returned by
+// Tigetstr, with up to nine numeric parameters.
+func Tiparm(str string, params ...int) (string, error) {
+	curses.CheckThread()
+	if len(params) > 9 {
+		return "", errors.New("terminfo: Tiparm takes at most 9 parameters")
+	}
+	var p [9]C.int
+	for i, v := range params {
+		p[i] = C.int(v)
+	}
+	s := C.CString(str)
+	defer C.free(unsafe.Pointer(s))
+	r := C._tiparm9(s, p[0], p[1], p[2], p[3], p[4], p[5], p[6], p[7], p[8])
+	if r == nil {
+		return "", errors.New("terminfo: tiparm failed")
+	}
+	return C.GoString(r), nil
+}
+
+// Putp writes str, such as the result of Tiparm, to the terminal, handling
+// any padding it contains.
+func Putp(str string) error {
+	curses.CheckThread()
+	s := C.CString(str)
+	defer C.free(unsafe.Pointer(s))
+	if C.putp(s) == C.ERR {
+		return errors.New("terminfo: putp failed")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package terminfo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/orofarne/gocurse/curses"
+)
+
+func TestLookup(t *testing.T) {
+	if err := Setupterm("xterm", int(os.Stderr.Fd())); err != nil {
+		t.Skip(err)
+	}
+	if n, err := Tigetnum("colors"); err != nil || n != 8 {
+		t.Errorf("Tigetnum(colors) = %d, %v; want 8", n, err)
+	}
+	if s, err := Tigetstr("smkx"); err != nil || s == "" {
+		t.Errorf("Tigetstr(smkx) = %q, %v", s, err)
+	}
+	if v, err := Tigetflag("am"); err != nil || !v {
+		t.Errorf("Tigetflag(am) = %v, %v; want true", v, err)
+	}
+
+	tests := []struct {
+		name string
+		get  func(string) error
+		cap  string
+		want error
+	}{
+		{"Tigetstr", func(s string) error { _, err := Tigetstr(s); return err }, "colors", ErrNotCapability},
+		{"Tigetnum", func(s string) error { _, err := Tigetnum(s); return err }, "smkx", ErrNotCapability},
+		{"Tigetflag", func(s string) error { _, err := Tigetflag(s); return err }, "colors", ErrNotCapability},
+		{"Tigetstr", func(s string) error { _, err := Tigetstr(s); return err }, "pfloc", ErrAbsent},
+		{"Tigetnum", func(s string) error { _, err := Tigetnum(s); return err }, "xmc", ErrAbsent},
+	}
+	for _, tt := range tests {
+		if err := tt.get(tt.cap); !errors.Is(err, tt.want) {
+			t.Errorf("%s(%s) error = %v, want %v", tt.name, tt.cap, err, tt.want)
+		}
+	}
+}
+
+func TestTputs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Tputs(&buf, "a$<5>b", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got[0] != 'a' || got[len(got)-1] != 'b' {
+		t.Errorf("Tputs wrote %q", got)
+	}
+}
+
+func TestThreadCheck(t *testing.T) {
+	if curses.UI() != nil {
+		t.Skip("a UI executor is running")
+	}
+	e := curses.NewExecutor()
+	defer e.Close()
+	curses.SetThreadCheck(true)
+	defer curses.SetThreadCheck(false)
+
+	calls := map[string]func(){
+		"Tigetnum": func() { Tigetnum("colors") },
+		"Tiparm":   func() { Tiparm("%p1%d", 1) },
+		"Tputs":    func() { Tputs(io.Discard, "x", 1) },
+	}
+	for name, f := range calls {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s off the UI thread did not panic", name)
+				}
+			}()
+			f()
+		}()
+		var p any
+		e.Sync(func() {
+			defer func() { p = recover() }()
+			f()
+		})
+		if p != nil {
+			t.Errorf("%s on the UI thread panicked: %v", name, p)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package terminfo
+
+// #define _Bool int
+// #define NCURSES_OPAQUE 1
+// #define _XOPEN_SOURCE_EXTENDED 1
+// #include <ncursesw/curses.h>
+// #include <ncursesw/term.h>
+// #include <stdlib.h>
+// extern int goTputsPutc(int c);
+import "C"
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/orofarne/gocurse/curses"
+)
+
+// tputs passes characters to a plain C callback, so the output of the call
+// in progress is collected here.
+var tputsOut struct {
+	sync.Mutex
+	buf []byte
+}
+
+//export goTputsPutc
+func goTputsPutc(c C.int) C.int {
+	tputsOut.buf = append(tputsOut.buf, byte(c))
+	return c
+}
+
+// Tputs writes str to w, expanding its padding for a change that affects
+// affcnt lines.
+func Tputs(w io.Writer, str string, affcnt int) error {
+	curses.CheckThread()
+	s := C.CString(str)
+	defer C.free(unsafe.Pointer(s))
+
+	tputsOut.Lock()
+	defer tputsOut.Unlock()
+	tputsOut.buf = tputsOut.buf[:0]
+	if C.tputs(s, C.int(affcnt), (*[0]byte)(C.goTputsPutc)) == C.ERR {
+		return errors.New("terminfo: tputs failed")
+	}
+	_, err := w.Write(tputsOut.buf)
+	return err
+}